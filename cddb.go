@@ -0,0 +1,67 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// leadInFrames is the length of the 2 second lead-in that precedes the first track on a CD.
+const leadInFrames = 2 * framesPerSecond
+
+// ErrUnknownDuration is returned when an operation needs the total length of the disc but it is unknown.
+var ErrUnknownDuration = errors.New("unknown total duration")
+
+// CDDBDiscID computes the CDDB (FreeDB) disc ID of the cue sheet.
+// It requires TotalLength to be set, since the disc length is part of the ID. The ID of a cue sheet
+// with several files cannot be computed, as the lengths of the files, hence the offsets of the
+// tracks on the disc, are unknown.
+func (c *CueSheet) CDDBDiscID() (uint32, error) {
+	if len(c.Tracks) == 0 {
+		return 0, errors.New("missing tracks")
+	}
+	if c.IsMultiFile() {
+		return 0, fmt.Errorf("%w: cue sheet with %d files", ErrUnknownDuration, len(c.Files))
+	}
+	if c.TotalLength == nil {
+		return 0, ErrUnknownDuration
+	}
+
+	var checksum int
+	for _, track := range c.Tracks {
+		checksum += digitSum((track.Index01.AbsoluteFrames() + leadInFrames) / framesPerSecond)
+	}
+
+	first := (c.Tracks[0].Index01.AbsoluteFrames() + leadInFrames) / framesPerSecond
+	leadOut := (c.TotalLength.AbsoluteFrames() + leadInFrames) / framesPerSecond
+	length := leadOut - first
+	if length <= 0 {
+		return 0, fmt.Errorf("total length %s is not after the first track", c.TotalLength)
+	}
+
+	return uint32(checksum%0xff)<<24 | uint32(length)<<8 | uint32(len(c.Tracks)), nil
+}
+
+// digitSum returns the sum of the decimal digits of n.
+func digitSum(n int) int {
+	var sum int
+	for ; n > 0; n /= 10 {
+		sum += n % 10
+	}
+	return sum
+}
+
+// TotalPlaybackTime returns the time from the start of the first track to the end of the disc.
+// It returns ErrUnknownDuration when the total length of the disc is unknown, which is also
+// the case with several files since only the length of the last one may be known.
+func (c *CueSheet) TotalPlaybackTime() (time.Duration, error) {
+	if c.TotalLength == nil || c.IsMultiFile() {
+		return 0, ErrUnknownDuration
+	}
+	var start IndexPoint
+	if len(c.Tracks) > 0 {
+		start = c.Tracks[0].Index01
+	}
+	frames := c.TotalLength.AbsoluteFrames() - start.AbsoluteFrames()
+	return FrameToDuration(frames), nil
+}
@@ -0,0 +1,54 @@
+package cuesheetgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCDDBDiscID(t *testing.T) {
+	cueSheet := allCueSheet
+	cueSheet.TotalLength = &IndexPoint{Timestamp: 2 * time.Minute}
+
+	// Track offsets including the lead-in are 3s and 62s, with digit sums 3 and 8.
+	// The disc is 122s - 3s = 119s (0x77) long and has 2 tracks.
+	discID, err := cueSheet.CDDBDiscID()
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x0B007702), discID)
+}
+
+func TestCDDBDiscIDUnknownLength(t *testing.T) {
+	cueSheet := allCueSheet
+	_, err := cueSheet.CDDBDiscID()
+	require.ErrorIs(t, err, ErrUnknownDuration)
+}
+
+func TestCDDBDiscIDLengthBeforeFirstTrack(t *testing.T) {
+	cueSheet := allCueSheet
+	cueSheet.TotalLength = &IndexPoint{}
+	_, err := cueSheet.CDDBDiscID()
+	require.ErrorContains(t, err, "is not after the first track")
+}
+
+func TestCDDBDiscIDMultipleFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	c.TotalLength = &IndexPoint{Timestamp: time.Minute}
+	_, err := c.CDDBDiscID()
+	require.ErrorIs(t, err, ErrUnknownDuration)
+	require.ErrorContains(t, err, "cue sheet with 2 files")
+
+	_, err = c.TotalPlaybackTime()
+	require.ErrorIs(t, err, ErrUnknownDuration)
+}
+
+func TestTotalPlaybackTime(t *testing.T) {
+	cueSheet := allCueSheet
+	_, err := cueSheet.TotalPlaybackTime()
+	require.ErrorIs(t, err, ErrUnknownDuration)
+
+	cueSheet.TotalLength = &IndexPoint{Timestamp: 2 * time.Minute, Frame: 30}
+	playbackTime, err := cueSheet.TotalPlaybackTime()
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Minute-time.Second+400*time.Millisecond, playbackTime)
+}
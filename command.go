@@ -0,0 +1,57 @@
+package cuesheetgo
+
+import "fmt"
+
+// CommandDef describes a cue sheet command and the number of parameters it accepts.
+type CommandDef struct {
+	Name string
+	// ExactParams is the exact number of parameters, when not zero.
+	// Otherwise the number of parameters is bounded by MinParams and MaxParams.
+	ExactParams int
+	MinParams   int
+	// MaxParams is the maximum number of parameters, zero meaning no limit.
+	MaxParams int
+}
+
+// The commands supported by the parser.
+var (
+	FileCommand      = CommandDef{Name: "FILE", MinParams: 2, MaxParams: 2}
+	PerformerCommand = CommandDef{Name: "PERFORMER", MinParams: 1}
+	TitleCommand     = CommandDef{Name: "TITLE", MinParams: 1}
+	TrackCommand     = CommandDef{Name: "TRACK", MinParams: 2, MaxParams: 2}
+	ISRCCommand      = CommandDef{Name: "ISRC", MinParams: 1, MaxParams: 1}
+	CatalogCommand   = CommandDef{Name: "CATALOG", MinParams: 1, MaxParams: 1}
+	IndexCommand     = CommandDef{Name: "INDEX", MinParams: 2, MaxParams: 2}
+	RemCommand       = CommandDef{Name: "REM", MinParams: 1}
+)
+
+// commands maps the name of every supported command to its definition.
+var commands = map[string]CommandDef{
+	FileCommand.Name:      FileCommand,
+	PerformerCommand.Name: PerformerCommand,
+	TitleCommand.Name:     TitleCommand,
+	TrackCommand.Name:     TrackCommand,
+	ISRCCommand.Name:      ISRCCommand,
+	CatalogCommand.Name:   CatalogCommand,
+	IndexCommand.Name:     IndexCommand,
+	RemCommand.Name:       RemCommand,
+}
+
+// String returns the name of the command.
+func (cmd CommandDef) String() string {
+	return cmd.Name
+}
+
+// validateParameters checks that the command accepts the given number of parameters.
+func (cmd CommandDef) validateParameters(parameters []string) error {
+	n := len(parameters)
+	switch {
+	case cmd.ExactParams != 0 && n != cmd.ExactParams:
+		return fmt.Errorf("%s: expected %d parameters, got %d", cmd, cmd.ExactParams, n)
+	case n < cmd.MinParams:
+		return fmt.Errorf("%s: expected at least %d parameters, got %d", cmd, cmd.MinParams, n)
+	case cmd.MaxParams != 0 && n > cmd.MaxParams:
+		return fmt.Errorf("%s: expected at most %d parameters, got %d", cmd, cmd.MaxParams, n)
+	}
+	return nil
+}
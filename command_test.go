@@ -0,0 +1,48 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommandDefString(t *testing.T) {
+	require.Equal(t, "FILE", FileCommand.String())
+	require.Equal(t, "REM", fmt.Sprint(RemCommand))
+}
+
+func TestCommandDefValidateParameters(t *testing.T) {
+	cmd := CommandDef{Name: "CMD", MinParams: 1, MaxParams: 2}
+	exact := CommandDef{Name: "EXACT", ExactParams: 2}
+	tcs := []struct {
+		name        string
+		cmd         CommandDef
+		parameters  string
+		expectedErr error
+	}{
+		{name: "Exact", cmd: exact, parameters: "a b"},
+		{name: "ExactTooFew", cmd: exact, parameters: "a", expectedErr: errors.New("EXACT: expected 2 parameters, got 1")},
+		{name: "ExactTooMany", cmd: exact, parameters: "a b c", expectedErr: errors.New("EXACT: expected 2 parameters, got 3")},
+		{name: "File", cmd: FileCommand, parameters: "sample.flac WAVE"},
+		{name: "FileTooFew", cmd: FileCommand, parameters: "WAVE", expectedErr: errors.New("FILE: expected at least 2 parameters, got 1")},
+		{name: "FileTooMany", cmd: FileCommand, parameters: "a b c WAVE", expectedErr: errors.New("FILE: expected at most 2 parameters, got 4")},
+		{name: "Unbounded", cmd: TitleCommand, parameters: "A very long title"},
+		{name: "Min", cmd: cmd, parameters: "a"},
+		{name: "Max", cmd: cmd, parameters: "a b"},
+		{name: "TooFew", cmd: cmd, parameters: "", expectedErr: errors.New("CMD: expected at least 1 parameters, got 0")},
+		{name: "TooMany", cmd: cmd, parameters: "a b c", expectedErr: errors.New("CMD: expected at most 2 parameters, got 3")},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cmd.validateParameters(strings.Fields(tc.parameters))
+			if tc.expectedErr != nil {
+				require.EqualError(t, err, tc.expectedErr.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -1,15 +1,17 @@
 package cuesheetgo
 
 import (
-	"bufio"
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
-	"time"
+	"unicode"
 )
 
 const (
@@ -19,80 +21,267 @@ const (
 
 	minLineFields = 2
 
-	fileParams  = 2
-	trackParams = 2
-	indexParams = 2
+	remParams = 2
 
-	maxTracks = 99
-)
+	maxTracks  = 99
+	maxIndices = 99
 
-type IndexPoint struct {
-	Frame     int
-	Timestamp time.Duration
-}
+	// noIndex marks that no INDEX command has been parsed yet for the current track.
+	noIndex = -1
+)
 
 // Track represents a single track in a cue sheet file.
 // Required fields: Index01, Type.
 type Track struct {
-	Type    string
-	Index01 IndexPoint
+	// Number is the 1-based position of the track in the cue sheet.
+	Number int       `json:"number" yaml:"number"`
+	Type   TrackType `json:"type" yaml:"type"`
+	Title  string    `json:"title,omitempty" xml:",omitempty" yaml:"title,omitempty"`
+	// Performer is the performer of the track, when it differs from the album performer.
+	Performer string `json:"performer,omitempty" xml:",omitempty" yaml:"performer,omitempty"`
+	// ISRC is the International Standard Recording Code of the track.
+	ISRC string `json:"isrc,omitempty" xml:",omitempty" yaml:"isrc,omitempty"`
+	// Index00 is the start of the pregap, nil when the track has none.
+	Index00 *IndexPoint `json:"index00,omitempty" xml:",omitempty" yaml:"index00,omitempty"`
+	Index01 IndexPoint  `json:"index01" yaml:"index01"`
+	// Indices holds the subindices following INDEX 01, starting at INDEX 02.
+	Indices []IndexPoint `json:"indices,omitempty" xml:"Index,omitempty" yaml:"indices,omitempty"`
+
+	TrackGain float64 `json:"track_gain,omitempty" xml:",omitempty" yaml:"track_gain,omitempty"`
+	TrackPeak float64 `json:"track_peak,omitempty" xml:",omitempty" yaml:"track_peak,omitempty"`
+
+	// File is the index in CueSheet.Files of the file in which the track starts.
+	File int `json:"file,omitempty" xml:",omitempty" yaml:"file,omitempty"`
+
+	// Comments holds the REM COMMENT values of the track, Remarks any other unrecognized REM line.
+	Comments []string `json:"comments,omitempty" xml:"Comment,omitempty" yaml:"comments,omitempty"`
+	Remarks  []string `json:"remarks,omitempty" xml:"Remark,omitempty" yaml:"remarks,omitempty"`
+}
+
+// FileEntry is an audio file referenced by a FILE command.
+type FileEntry struct {
+	FileName string      `json:"file_name" xml:",chardata" yaml:"file_name"`
+	Format   AudioFormat `json:"audio_format" xml:"Format,attr" yaml:"audio_format"`
 }
 
 // CueSheet represents the contents of a cue sheet file.
-// Required fields: FileName, Format, Tracks.
+// Required fields: Files, Tracks.
 type CueSheet struct {
-	AlbumPerformer string
-	Format         string
-	FileName       string
-	Tracks         []Track
+	AlbumPerformer string `json:"album_performer,omitempty" xml:",omitempty" yaml:"album_performer,omitempty"`
+	AlbumTitle     string `json:"album_title,omitempty" xml:",omitempty" yaml:"album_title,omitempty"`
+	// Files holds the FILE commands in order. Each track refers to the file it starts in.
+	Files  []FileEntry `json:"files" xml:"Files>File" yaml:"files"`
+	Tracks TrackList   `json:"tracks" xml:"Tracks>Track" yaml:"tracks"`
+
+	// Catalog is the Media Catalog Number of the CATALOG command.
+	Catalog string `json:"catalog,omitempty" xml:",omitempty" yaml:"catalog,omitempty"`
+	// Barcode is the product barcode of the REM EAN, UPC or BARCODE command.
+	Barcode string `json:"barcode,omitempty" xml:",omitempty" yaml:"barcode,omitempty"`
+
+	AlbumGain float64 `json:"album_gain,omitempty" xml:",omitempty" yaml:"album_gain,omitempty"`
+	AlbumPeak float64 `json:"album_peak,omitempty" xml:",omitempty" yaml:"album_peak,omitempty"`
+
+	// DiscID is the CDDB disc ID of the REM DISCID command, nil when absent.
+	DiscID *uint32 `json:"disc_id,omitempty" xml:",omitempty" yaml:"-"`
+	// MusicBrainzDiscID is the disc ID of the REM MUSICBRAINZ_DISCID command.
+	MusicBrainzDiscID string `json:"musicbrainz_discid,omitempty" xml:",omitempty" yaml:"musicbrainz_discid,omitempty"`
+
+	// Date is the release date of the REM DATE command, usually a year.
+	Date string `json:"date,omitempty" xml:",omitempty" yaml:"date,omitempty"`
+
+	DiscNumber int `json:"disc_number,omitempty" xml:",omitempty" yaml:"disc_number,omitempty"`
+	TotalDiscs int `json:"total_discs,omitempty" xml:",omitempty" yaml:"total_discs,omitempty"`
+
+	// Comments holds the REM COMMENT values, Remarks any other unrecognized REM line.
+	Comments []string `json:"comments,omitempty" xml:"Comment,omitempty" yaml:"comments,omitempty"`
+	Remarks  []string `json:"remarks,omitempty" xml:"Remark,omitempty" yaml:"remarks,omitempty"`
+
+	// TotalLength is the length of the audio file, when known.
+	TotalLength *IndexPoint `json:"total_length,omitempty" xml:",omitempty" yaml:"total_length,omitempty"`
+}
+
+// musicBrainzDiscIDRegexp matches a MusicBrainz disc ID: 28 characters of base64url
+// with the "." and "_" substitutions used by MusicBrainz.
+var musicBrainzDiscIDRegexp = regexp.MustCompile(`^[A-Za-z0-9._-]{28}$`)
+
+// ParseOptions configures the behavior of ParseWithOptions.
+type ParseOptions struct {
+	// Logger receives the parser log records. When nil, the global slog logger is used.
+	Logger *slog.Logger
+	// LenientMode skips unknown commands, logging them at debug level, instead of failing.
+	// Known commands with invalid parameters are still rejected, unless ErrorOnUnknownCommands is set.
+	LenientMode bool
+	// AllowDuplicateTracks accepts a TRACK command repeating the number of the previous one,
+	// as written by some old rippers. The repeated track is parsed as a new track and every
+	// track is numbered by its position.
+	AllowDuplicateTracks bool
+	// MaxLineLength is the maximum length in bytes of a line, line ending excluded.
+	// Longer lines make parsing fail with a ParseError. When zero, the limit is 1 MiB.
+	MaxLineLength int
+	// SkipValidation returns the parsed cue sheet without validating it, so that it may be
+	// incomplete, for instance without file or tracks. Every line must still be parsed correctly.
+	SkipValidation bool
+	// TrackByteOffsets sets the byte offset of the offending line in ParseError.
+	TrackByteOffsets bool
+	// StrictOrdering requires the album CATALOG, PERFORMER and TITLE commands before FILE,
+	// and FILE before the first TRACK.
+	StrictOrdering bool
+	// StrictRedBook validates the cue sheet with ValidateStrict instead of the default rules.
+	StrictRedBook bool
+	// ExtendedDiscCapacity allows discs of up to 80 minutes with StrictRedBook, see RedBookOptions.
+	ExtendedDiscCapacity bool
+	// OnUnknownCommand is called with every unknown command and its parameters, taking
+	// precedence over LenientMode. Parsing stops with a ParseError wrapping the returned
+	// error, if any, and goes on with the next line otherwise.
+	OnUnknownCommand func(cmd string, params []string) error
+	// AllowZeroFirstTrackIndex accepts an INDEX 00 at 00:00:00 in the first track when its
+	// INDEX 01 is at 00:00:00 too, as written by some tools. Such an empty pregap is dropped.
+	AllowZeroFirstTrackIndex bool
+	// ErrorOnUnknownCommands rejects unknown commands even in LenientMode, for instance to
+	// detect the extensions of a tool. OnUnknownCommand still takes precedence over it.
+	// Combined with LenientMode, every other invalid line is skipped and logged at debug level,
+	// so that unknown commands are the only line errors.
+	ErrorOnUnknownCommands bool
+	// CollectAllErrors reports every rule broken by an invalid cue sheet in a MultiError,
+	// instead of the first one only.
+	CollectAllErrors bool
+	// RequireKnownFormats rejects the FILE formats outside of the cue sheet specification,
+	// see AudioFormat.Valid. Other formats, such as FLAC, are accepted by default.
+	RequireKnownFormats bool
 }
 
 // Parse reads the cue sheet data from the provided reader and returns a parsed CueSheet struct.
 func Parse(reader io.Reader) (*CueSheet, error) {
-	scanner := bufio.NewScanner(reader)
-	c := &CueSheet{Tracks: []Track{}}
-
-	var lineNr int
-	for scanner.Scan() {
-		line := strings.Trim(scanner.Text(), trimChars)
-		lineNr++
-		if line == "" {
-			continue
-		}
-		if err := c.parseLine(line); err != nil {
-			return nil, fmt.Errorf("line %d:\t%s:\n\t%w", lineNr, line, err)
+	return ParseWithOptions(reader, ParseOptions{})
+}
+
+// ParseBytes parses the cue sheet contained in data.
+func ParseBytes(data []byte) (*CueSheet, error) {
+	return Parse(bytes.NewReader(data))
+}
+
+// ParseWithOptions is like Parse but allows configuring the parser through opts.
+func ParseWithOptions(reader io.Reader, opts ParseOptions) (*CueSheet, error) {
+	p := NewParserWithOptions(reader, opts)
+	for {
+		if _, err := p.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
 		}
 	}
-	if err := c.validate(); err != nil {
-		return nil, fmt.Errorf("invalid cue sheet: %w", err)
-	}
-	slog.Info("cue sheet parsed correctly", "lines", lineNr, "file", c.FileName, "format", c.Format, "tracks", len(c.Tracks))
+	c := p.c
+	p.logger.Info("cue sheet parsed correctly", "lines", p.lineNr, "file", c.FileName(), "format", c.Format(), "tracks", len(c.Tracks))
 	return c, nil
 }
 
-func (c *CueSheet) parseLine(line string) error {
-	fields := strings.Fields(line)
+func (p *Parser) parseLine(line string) (Event, error) {
+	p.fields = appendFields(p.fields[:0], line)
+	fields := p.fields
 	if len(fields) < minLineFields {
-		return fmt.Errorf("expected at least %d fields, got %d", minLineFields, len(fields))
+		return p.invalidLine(fmt.Errorf("expected at least %d fields, got %d", minLineFields, len(fields)))
 	}
 
-	var err error
-	command := fields[0]
+	command, ok := commands[fields[0]]
+	if !ok {
+		if p.opts.OnUnknownCommand != nil {
+			// The fields are reused by the next line, the callback may keep its own copy.
+			return nil, p.opts.OnUnknownCommand(fields[0], slices.Clone(fields[1:]))
+		}
+		if p.opts.LenientMode && !p.opts.ErrorOnUnknownCommands {
+			p.logger.Debug("skipping unknown command", "line", p.lineNr, "command", fields[0])
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected command: %s", fields[0])
+	}
+	// Once a TRACK is skipped as invalid, its lines are skipped too instead of being attached
+	// to the previous track, until the next valid TRACK. parseTrack clears skipTrack.
+	if command == TrackCommand {
+		p.skipTrack = true
+	} else if p.skipTrack && command != FileCommand {
+		p.logger.Debug("skipping line of invalid track", "line", p.lineNr, "command", command)
+		return nil, nil
+	}
 	parameters := fields[1:]
+	if err := command.validateParameters(parameters); err != nil {
+		return p.invalidLine(fmt.Errorf("error parsing %q command: %w", command, err))
+	}
+	if p.opts.StrictOrdering {
+		if err := p.checkOrdering(command); err != nil {
+			return p.invalidLine(err)
+		}
+	}
+
+	var err error
 	switch command {
-	case "FILE":
-		err = c.parseFile(parameters)
-	case "PERFORMER":
-		err = c.parsePerformer(parameters)
-	case "TRACK":
-		err = c.parseTrack(parameters)
-	case "INDEX":
-		err = c.parseIndex(parameters)
-	default:
-		return fmt.Errorf("unexpected command: %s", command)
+	case FileCommand:
+		err = p.parseFile(parameters)
+	case PerformerCommand:
+		err = p.c.parsePerformer(parameters)
+	case TitleCommand:
+		err = p.c.parseTitle(parameters)
+	case TrackCommand:
+		err = p.parseTrack(parameters)
+	case ISRCCommand:
+		err = p.c.parseISRC(parameters)
+	case CatalogCommand:
+		err = p.c.parseCatalog(parameters)
+	case IndexCommand:
+		err = p.parseIndex(parameters)
+	case RemCommand:
+		err = p.c.parseRem(parameters)
 	}
 	if err != nil {
-		return fmt.Errorf("error parsing %q command: %w", command, err)
+		return p.invalidLine(fmt.Errorf("error parsing %q command: %w", command, err))
+	}
+	return p.event(command, parameters), nil
+}
+
+// invalidLine returns err, unless ParseOptions.LenientMode and ParseOptions.ErrorOnUnknownCommands
+// are both set. The line is then skipped and err logged at debug level.
+func (p *Parser) invalidLine(err error) (Event, error) {
+	if p.opts.LenientMode && p.opts.ErrorOnUnknownCommands {
+		p.logger.Debug("skipping invalid line", "line", p.lineNr, "error", err)
+		return nil, nil
+	}
+	return nil, err
+}
+
+// appendFields appends the fields of line, split around white space as by strings.Fields,
+// to fields. The fields are substrings of line, so that nothing is allocated once fields
+// has grown to the largest number of fields of a line.
+func appendFields(fields []string, line string) []string {
+	start := -1
+	for i, r := range line {
+		switch {
+		case !unicode.IsSpace(r):
+			if start < 0 {
+				start = i
+			}
+		case start >= 0:
+			fields = append(fields, line[start:i])
+			start = -1
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, line[start:])
+	}
+	return fields
+}
+
+// checkOrdering checks that the album commands come before FILE, and FILE before TRACK.
+// INDEX is already required to follow a TRACK.
+func (p *Parser) checkOrdering(command CommandDef) error {
+	fileParsed := len(p.c.Files) > 0
+	switch command {
+	case CatalogCommand, PerformerCommand, TitleCommand:
+		if fileParsed && len(p.c.Tracks) == 0 {
+			return fmt.Errorf("%s must come before FILE", command)
+		}
+	case TrackCommand:
+		if !fileParsed {
+			return errors.New("TRACK must come after FILE")
+		}
 	}
 	return nil
 }
@@ -106,126 +295,456 @@ func assignValue[T comparable](val T, field *T) error {
 	return nil
 }
 
-func parseString(val string, field *string) error {
+func parseString[T ~string](val string, field *T) error {
 	val = strings.Trim(val, trimChars)
-	return assignValue(val, field)
+	return assignValue(T(val), field)
 }
 
-func (c *CueSheet) parseFile(parameters []string) error {
-	if len(parameters) != fileParams {
-		return fmt.Errorf("FILE: expected %d parameters, got %d", fileParams, len(parameters))
+// parseFile adds a file to the cue sheet. The following tracks start in this file.
+// With RequireKnownFormats, the format must be one of the specification.
+func (p *Parser) parseFile(parameters []string) error {
+	format := AudioFormat(strings.Trim(parameters[1], trimChars))
+	if p.opts.RequireKnownFormats && !format.Valid() {
+		return fmt.Errorf("unsupported file format: %s", format)
 	}
-	last := len(parameters) - 1
-	if err := parseString(parameters[last], &c.Format); err != nil {
-		return fmt.Errorf("error parsing FILE format: %w", err)
+	p.c.Files = append(p.c.Files, FileEntry{
+		FileName: strings.Trim(parameters[0], trimChars),
+		Format:   format,
+	})
+	return nil
+}
+
+// parsePerformer sets the performer of the current track, or of the album before the first track.
+func (c *CueSheet) parsePerformer(parameters []string) error {
+	field := &c.AlbumPerformer
+	if len(c.Tracks) > 0 {
+		field = &c.Tracks[len(c.Tracks)-1].Performer
 	}
-	if err := parseString(strings.Join(parameters[:last], " "), &c.FileName); err != nil {
-		return fmt.Errorf("error parsing FILE name: %w", err)
+	if err := parseString(strings.Join(parameters, " "), field); err != nil {
+		return fmt.Errorf("error parsing PERFORMER parameters: %w", err)
 	}
 	return nil
 }
 
-func (c *CueSheet) parsePerformer(parameters []string) error {
-	if err := parseString(strings.Join(parameters, " "), &c.AlbumPerformer); err != nil {
-		return fmt.Errorf("error parsing PERFORMER parameters")
+// parseTitle sets the title of the current track, or of the album before the first track.
+func (c *CueSheet) parseTitle(parameters []string) error {
+	field := &c.AlbumTitle
+	if len(c.Tracks) > 0 {
+		field = &c.Tracks[len(c.Tracks)-1].Title
+	}
+	if err := parseString(strings.Join(parameters, " "), field); err != nil {
+		return fmt.Errorf("error parsing TITLE parameters: %w", err)
 	}
 	return nil
 }
 
-func (c *CueSheet) parseTrack(parameters []string) error {
-	if len(parameters) != trackParams {
-		return fmt.Errorf("TRACK: expected %d parameters, got %d", 2, len(parameters))
+// parseISRC sets the ISRC of the current track.
+func (c *CueSheet) parseISRC(parameters []string) error {
+	if len(c.Tracks) == 0 {
+		return errors.New("ISRC: no current track")
+	}
+	isrc := strings.Trim(parameters[0], trimChars)
+	if !ValidateISRC(isrc) {
+		return fmt.Errorf("invalid ISRC: %q", isrc)
+	}
+	return assignValue(isrc, &c.Tracks[len(c.Tracks)-1].ISRC)
+}
+
+func (c *CueSheet) parseCatalog(parameters []string) error {
+	mcn := strings.Trim(parameters[0], trimChars)
+	if !isCatalogFormat(mcn) {
+		return fmt.Errorf("invalid catalog number, expected %d digits: %q", catalogLength, mcn)
 	}
+	if !ValidateCatalog(mcn) {
+		return fmt.Errorf("invalid catalog number %q: expected check digit %d", mcn, catalogCheckDigit(mcn))
+	}
+	return assignValue(mcn, &c.Catalog)
+}
+
+func (p *Parser) parseTrack(parameters []string) error {
 	nr := parameters[0]
 	typ := parameters[1]
 
-	if err := c.isNextTrack(nr); err != nil {
+	trackNr, err := p.isNextTrack(nr)
+	if err != nil {
 		return fmt.Errorf("invalid track number: %w", err)
 	}
 
-	var track Track
+	c := p.c
+	// Without StrictOrdering a track may come before the first FILE, it then starts in it.
+	track := Track{Number: c.nextTrackNumber(), File: max(len(c.Files)-1, 0)}
+	if !TrackType(typ).IsValid() {
+		return fmt.Errorf("unsupported track type: %s", typ)
+	}
 	if err := parseString(typ, &track.Type); err != nil {
 		return fmt.Errorf("error parsing track type: %w", err)
 	}
 	c.Tracks = append(c.Tracks, track)
+	p.lastTrack = trackNr
+	p.lastIndex = noIndex
+	p.skipTrack = false
 	return nil
 }
 
-func (c *CueSheet) isNextTrack(nr string) error {
+// nextTrackNumber returns the number of the track that follows the current last track.
+func (c *CueSheet) nextTrackNumber() int {
+	return len(c.Tracks) + 1
+}
+
+// isNextTrack parses the track number nr and checks that it follows the last parsed track.
+// With AllowDuplicateTracks, the number of the last parsed track is accepted again.
+func (p *Parser) isNextTrack(nr string) (int, error) {
 	trackNr, err := strconv.Atoi(nr)
 	if err != nil {
-		return fmt.Errorf("failed to parse track number: %w", err)
+		return 0, fmt.Errorf("failed to parse track number: %w", err)
 	}
-	nextTrackNr := len(c.Tracks) + 1
-	if trackNr != nextTrackNr {
-		return fmt.Errorf("expected track number %d, got %d", nextTrackNr, trackNr)
+	duplicate := p.opts.AllowDuplicateTracks && p.lastTrack > 0 && trackNr == p.lastTrack
+	if nextTrackNr := p.lastTrack + 1; trackNr != nextTrackNr && !duplicate {
+		return 0, fmt.Errorf("expected track number %d, got %d", nextTrackNr, trackNr)
 	}
 	if trackNr > maxTracks {
-		return fmt.Errorf("cannot have more than %d tracks", maxTracks)
+		return 0, fmt.Errorf("cannot have more than %d tracks", maxTracks)
 	}
-	return nil
+	return trackNr, nil
 }
 
-func (c *CueSheet) parseIndex(parameters []string) error {
-	if len(parameters) != indexParams {
-		return fmt.Errorf("INDEX: expected %d parameters, got %d", 2, len(parameters))
-	}
+func (p *Parser) parseIndex(parameters []string) error {
 	nr := parameters[0]
 	indexPoint := parameters[1]
 
+	if len(p.c.Tracks) == 0 {
+		return errors.New("INDEX: no current track")
+	}
 	indexNr, err := strconv.Atoi(nr)
 	if err != nil {
 		return fmt.Errorf("failed to parse index number: %w", err)
 	}
-	if indexNr != 1 {
-		return fmt.Errorf("expected index number 1, got %d", indexNr)
+	if err = p.isNextIndex(indexNr); err != nil {
+		return err
+	}
+
+	index, err := ParseIndexPoint(indexPoint)
+	if err != nil {
+		return err
+	}
+	track := &p.c.Tracks[len(p.c.Tracks)-1]
+	switch indexNr {
+	case 0:
+		track.Index00 = &index
+	case 1:
+		track.Index01 = index
+		if p.opts.AllowZeroFirstTrackIndex && len(p.c.Tracks) == 1 && isZeroPregap(track) {
+			track.Index00 = nil
+		}
+	default:
+		track.Indices = append(track.Indices, index)
+	}
+	p.lastIndex = indexNr
+	return nil
+}
+
+// isZeroPregap reports whether the track has an INDEX 00 and an INDEX 01 both at 00:00:00.
+func isZeroPregap(t *Track) bool {
+	return t.Index00 != nil && t.Index00.IsZero() && t.Index01.IsZero()
+}
+
+// isNextIndex checks that indices are sequential, starting from INDEX 00 or INDEX 01.
+func (p *Parser) isNextIndex(indexNr int) error {
+	if p.lastIndex == noIndex {
+		if indexNr != 0 && indexNr != 1 {
+			return fmt.Errorf("expected index number 1, got %d", indexNr)
+		}
+		return nil
+	}
+	if indexNr != p.lastIndex+1 {
+		return fmt.Errorf("expected index number %d, got %d", p.lastIndex+1, indexNr)
+	}
+	if indexNr > maxIndices {
+		return fmt.Errorf("cannot have more than %d indices", maxIndices)
+	}
+	return nil
+}
+
+// remKeys are the REM keys parsed into the fields of the cue sheet and of its tracks.
+// A remark starting with one of them would not be read back as a remark.
+var remKeys = map[string]bool{
+	"COMMENT":               true,
+	"TOTALLENGTH":           true,
+	"REPLAYGAIN_ALBUM_GAIN": true,
+	"REPLAYGAIN_ALBUM_PEAK": true,
+	"REPLAYGAIN_TRACK_GAIN": true,
+	"REPLAYGAIN_TRACK_PEAK": true,
+	"DATE":                  true,
+	"DISCID":                true,
+	"MUSICBRAINZ_DISCID":    true,
+	"EAN":                   true,
+	"UPC":                   true,
+	"BARCODE":               true,
+	"DISCNUMBER":            true,
+	"TOTALDISCS":            true,
+}
+
+// parseRem parses the REM comments that carry known metadata. Other comments are kept as remarks.
+func (c *CueSheet) parseRem(parameters []string) error {
+	key := parameters[0]
+	value := strings.Join(parameters[1:], " ")
+	switch key {
+	case "COMMENT":
+		c.parseComment(value)
+		return nil
+	case "TOTALLENGTH":
+		return c.parseTotalLength(value)
+	case "REPLAYGAIN_ALBUM_GAIN":
+		return parseGain(value, &c.AlbumGain)
+	case "REPLAYGAIN_ALBUM_PEAK":
+		return parseGain(value, &c.AlbumPeak)
+	case "DATE":
+		return parseString(value, &c.Date)
+	case "DISCID":
+		return c.parseDiscID(value)
+	case "MUSICBRAINZ_DISCID":
+		return c.parseMusicBrainzDiscID(value)
+	case "EAN", "UPC", "BARCODE":
+		// The keys are aliases, a barcode may only be set by one of them.
+		return parseString(value, &c.Barcode)
+	case "DISCNUMBER":
+		return parsePositiveInt(value, &c.DiscNumber)
+	case "TOTALDISCS":
+		return parsePositiveInt(value, &c.TotalDiscs)
+	}
+	if len(c.Tracks) > 0 {
+		return c.Tracks[len(c.Tracks)-1].parseRem(parameters)
+	}
+	switch key {
+	case "REPLAYGAIN_TRACK_GAIN", "REPLAYGAIN_TRACK_PEAK":
+		return fmt.Errorf("%s: no current track", key)
+	}
+	c.Remarks = append(c.Remarks, strings.Join(parameters, " "))
+	return nil
+}
+
+// parseRem parses the REM keys that belong to a track. Any other key is kept as a remark
+// of the track, so that it is written back inside the same TRACK block.
+func (t *Track) parseRem(parameters []string) error {
+	value := strings.Join(parameters[1:], " ")
+	switch parameters[0] {
+	case "REPLAYGAIN_TRACK_GAIN":
+		return parseGain(value, &t.TrackGain)
+	case "REPLAYGAIN_TRACK_PEAK":
+		return parseGain(value, &t.TrackPeak)
+	}
+	t.Remarks = append(t.Remarks, strings.Join(parameters, " "))
+	return nil
+}
+
+// parseComment appends a comment to the current track, or to the cue sheet before the first track.
+func (c *CueSheet) parseComment(value string) {
+	comment := strings.Trim(value, trimChars)
+	if len(c.Tracks) == 0 {
+		c.Comments = append(c.Comments, comment)
+		return
+	}
+	track := &c.Tracks[len(c.Tracks)-1]
+	track.Comments = append(track.Comments, comment)
+}
+
+func parsePositiveInt(value string, field *int) error {
+	n, err := strconv.Atoi(strings.Trim(value, trimChars))
+	if err != nil {
+		return fmt.Errorf("failed to parse number: %w", err)
+	}
+	if n <= 0 {
+		return fmt.Errorf("expected a positive number, got %d", n)
+	}
+	return assignValue(n, field)
+}
+
+// parseGain parses a ReplayGain value, optionally followed by the "dB" unit.
+func parseGain(value string, field *float64) error {
+	value = strings.TrimSuffix(strings.Trim(value, trimChars), " dB")
+	gain, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse ReplayGain value: %w", err)
+	}
+	return assignValue(gain, field)
+}
+
+// parseDiscID parses a CDDB disc ID, written as 8 hexadecimal digits.
+// A pointer is used so that 00000000 can be told apart from a missing disc ID.
+func (c *CueSheet) parseDiscID(value string) error {
+	if c.DiscID != nil {
+		return fmt.Errorf("field already set: %08X", *c.DiscID)
+	}
+	value = strings.Trim(value, trimChars)
+	if len(value) != 8 {
+		return fmt.Errorf("invalid disc ID, expected 8 hexadecimal digits: %q", value)
+	}
+	discID, err := strconv.ParseUint(value, 16, 32)
+	if err != nil {
+		return fmt.Errorf("failed to parse disc ID: %w", err)
+	}
+	c.DiscID = new(uint32)
+	*c.DiscID = uint32(discID)
+	return nil
+}
+
+func (c *CueSheet) parseMusicBrainzDiscID(value string) error {
+	discID := strings.Trim(value, trimChars)
+	if !musicBrainzDiscIDRegexp.MatchString(discID) {
+		return fmt.Errorf("invalid MusicBrainz disc ID: %q", discID)
 	}
+	return assignValue(discID, &c.MusicBrainzDiscID)
+}
 
-	var minutes, seconds, frames int
-	if _, err = fmt.Sscanf(indexPoint, "%2d:%2d:%2d", &minutes, &seconds, &frames); err != nil {
-		return fmt.Errorf("error parsing timestamp and frame: %w", err)
+func (c *CueSheet) parseTotalLength(value string) error {
+	if value == "" {
+		return fmt.Errorf("TOTALLENGTH: expected %d parameters, got 1", remParams)
+	}
+	if c.TotalLength != nil {
+		return fmt.Errorf("field already set: %v", c.TotalLength)
+	}
+	totalLength, err := ParseIndexPoint(strings.Trim(value, trimChars))
+	if err != nil {
+		return fmt.Errorf("error parsing TOTALLENGTH: %w", err)
 	}
-	duration := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
-	index := IndexPoint{Timestamp: duration, Frame: frames}
-	c.Tracks[len(c.Tracks)-1].Index01 = index
+	c.TotalLength = &totalLength
 	return nil
 }
 
 // validate checks if the cue sheet has FILE and at least one TRACK command with INDEX 01.
+// It returns the first rule broken by the cue sheet.
 func (c *CueSheet) validate() error {
-	if c.FileName == "" {
-		return errors.New("missing file name")
+	if errs := c.validationErrors(); len(errs) > 0 {
+		return errs[0]
 	}
-	if c.Format == "" {
-		return errors.New("missing file format")
+	return nil
+}
+
+// validationErrors returns every rule broken by the cue sheet, in the order they are checked.
+func (c *CueSheet) validationErrors() []error {
+	var errs []error
+	if len(c.Files) == 0 {
+		errs = append(errs, errors.New("missing file name"))
+	}
+	for i, file := range c.Files {
+		if file.FileName == "" {
+			errs = append(errs, fmt.Errorf("file %d: missing file name", i+1))
+		}
+		if file.Format == "" {
+			errs = append(errs, fmt.Errorf("file %d: missing file format", i+1))
+		}
 	}
 	if len(c.Tracks) == 0 {
-		return errors.New("missing tracks")
+		return append(errs, errors.New("missing tracks"))
 	}
-	if err := c.validateTracks(); err != nil {
-		return fmt.Errorf("invalid tracks: %w", err)
+	if len(c.Tracks) > maxTracks {
+		errs = append(errs, fmt.Errorf("track count %d exceeds maximum of %d", len(c.Tracks), maxTracks))
 	}
-	return nil
+	for _, err := range c.trackErrors() {
+		errs = append(errs, fmt.Errorf("invalid tracks: %w", err))
+	}
+	if err := c.validateTrackFiles(); err != nil {
+		errs = append(errs, fmt.Errorf("invalid tracks: %w", err))
+	}
+	if c.DiscNumber != 0 && c.TotalDiscs != 0 && c.DiscNumber > c.TotalDiscs {
+		errs = append(errs, fmt.Errorf("disc number %d exceeds total discs %d", c.DiscNumber, c.TotalDiscs))
+	}
+	if c.TotalLength != nil {
+		last := c.Tracks[len(c.Tracks)-1].Index01
+		if c.TotalLength.LessThanOrEqual(last) {
+			errs = append(errs, fmt.Errorf("total length %s is not after the last track", c.TotalLength))
+		}
+	}
+	return errs
 }
 
+// validateTracks returns the first error found in the tracks.
 func (c *CueSheet) validateTracks() error {
+	if errs := c.trackErrors(); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+// trackErrors returns every error found in the tracks, from the first track to the last.
+func (c *CueSheet) trackErrors() []error {
+	var errs []error
 	for i, track := range c.Tracks {
 		if track.Type == "" {
-			return errors.New("missing type")
+			errs = append(errs, errors.New("missing type"))
+		}
+		if track.Number != i+1 {
+			errs = append(errs, fmt.Errorf("track %d: unexpected track number %d", i+1, track.Number))
 		}
-		if i < len(c.Tracks)-1 {
+		// A fault of the INDEX 00 and INDEX 01 pair is reported once, without the ordering
+		// and overlap errors it implies.
+		indices := track.IndexPoints()
+		switch {
+		case track.missingIndex01():
+			errs = append(errs, fmt.Errorf("track %d: missing INDEX 01", i+1))
+			continue
+		case track.Index00 != nil && track.Index00.GreaterThanOrEqual(track.Index01):
+			errs = append(errs, fmt.Errorf("track %d: INDEX 00 must be before INDEX 01", i+1))
+		default:
+			if err := validateTrackIndices(indices); err != nil {
+				errs = append(errs, fmt.Errorf("track %d: %w", i+1, err))
+			}
+		}
+		// Index points restart from zero in every file.
+		if i < len(c.Tracks)-1 && c.Tracks[i+1].File == track.File && !c.Tracks[i+1].missingIndex01() {
 			var (
-				timestamp = track.Index01.Timestamp
-				frame     = track.Index01.Frame
-
-				nextTrack     = c.Tracks[i+1]
-				nextTimestamp = nextTrack.Index01.Timestamp
-				nextFrame     = nextTrack.Index01.Frame
+				last = indices[len(indices)-1]
+				next = c.Tracks[i+1].IndexPoints()[0]
 			)
-			if timestamp > nextTimestamp || (timestamp == nextTimestamp && frame >= nextFrame) {
-				return fmt.Errorf("overlapping indices in tracks %d and %d", i+1, i+2)
+			if last.GreaterThanOrEqual(next) {
+				errs = append(errs, fmt.Errorf("overlapping indices in tracks %d and %d", i+1, i+2))
 			}
 		}
 	}
+	return errs
+}
+
+// missingIndex01 reports whether the track has no INDEX 01. INDEX 01 may be at 00:00:00,
+// it is only known to be missing after a later INDEX 00.
+func (t *Track) missingIndex01() bool {
+	return t.Index00 != nil && !t.Index00.IsZero() && t.Index01.IsZero()
+}
+
+// validateTrackFiles checks that every track refers to a file of the cue sheet,
+// and that the files are in the order of the tracks.
+func (c *CueSheet) validateTrackFiles() error {
+	for i, track := range c.Tracks {
+		if track.File < 0 || track.File >= len(c.Files) {
+			return fmt.Errorf("track %d: unknown file %d", i+1, track.File)
+		}
+		if i > 0 && track.File < c.Tracks[i-1].File {
+			return fmt.Errorf("track %d: file %d comes before the file of the previous track", i+1, track.File)
+		}
+	}
+	return nil
+}
+
+// validateTrackIndices checks that the index points of a track are strictly increasing.
+func validateTrackIndices(indices []IndexPoint) error {
+	for i := 1; i < len(indices); i++ {
+		var (
+			prev = indices[i-1]
+			curr = indices[i]
+		)
+		if prev.GreaterThanOrEqual(curr) {
+			return fmt.Errorf("index %s is not after %s", curr, prev)
+		}
+	}
 	return nil
 }
+
+// IndexPoints returns all the index points of the track in order: INDEX 00 when set,
+// INDEX 01 and the following subindices.
+func (t *Track) IndexPoints() []IndexPoint {
+	indices := make([]IndexPoint, 0, len(t.Indices)+2)
+	if t.Index00 != nil {
+		indices = append(indices, *t.Index00)
+	}
+	indices = append(indices, t.Index01)
+	return append(indices, t.Indices...)
+}
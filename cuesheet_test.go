@@ -1,11 +1,14 @@
 package cuesheetgo
 
 import (
+	"bufio"
+	"bytes"
 	"embed"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"path"
 	"strings"
 	"testing"
@@ -25,29 +28,33 @@ type testCase struct {
 }
 
 var minimalCueSheet = CueSheet{
-	FileName: "sample.flac",
-	Format:   "WAVE",
+	Files: []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
 	Tracks: []Track{
 		{
-			Type: "AUDIO",
+			Number: 1,
+			Type:   "AUDIO",
 		},
 	},
 }
 
 var allCueSheet = CueSheet{
 	AlbumPerformer: "Sample Album Artist",
-	FileName:       "sample.flac",
-	Format:         "WAVE",
+	AlbumTitle:     "Sample Album",
+	Files:          []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
 	Tracks: []Track{
 		{
-			Type: "AUDIO",
+			Number: 1,
+			Type:   "AUDIO",
+			Title:  "First Track",
 			Index01: IndexPoint{
 				Frame:     0,
 				Timestamp: time.Duration(1) * time.Second,
 			},
 		},
 		{
-			Type: "AUDIO",
+			Number: 2,
+			Type:   "AUDIO",
+			Title:  "Second Track",
 			Index01: IndexPoint{
 				Frame:     0,
 				Timestamp: time.Duration(1) * time.Minute,
@@ -56,6 +63,176 @@ var allCueSheet = CueSheet{
 	},
 }
 
+var replayGainCueSheet = CueSheet{
+	Files:     []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	AlbumGain: -7.89,
+	AlbumPeak: 0.988831,
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+			Index01: IndexPoint{
+				Frame:     0,
+				Timestamp: time.Duration(1) * time.Second,
+			},
+			TrackGain: -6.5,
+			TrackPeak: 0.95,
+		},
+		{
+			Number: 2,
+			Type:   "AUDIO",
+			Index01: IndexPoint{
+				Frame:     0,
+				Timestamp: time.Duration(1) * time.Minute,
+			},
+			TrackGain: -8.12,
+			TrackPeak: 0.988831,
+		},
+	},
+}
+
+var discNumberCueSheet = CueSheet{
+	Files:      []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	DiscNumber: 1,
+	TotalDiscs: 2,
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+		},
+	},
+}
+
+var dateCueSheet = CueSheet{
+	Files: []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	Date:  "1989-07",
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+		},
+	},
+}
+
+var musicBrainzCueSheet = CueSheet{
+	Files:             []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	MusicBrainzDiscID: "49HHV7Eb8UKF3aQiNmu1GR8vKTY-",
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+		},
+	},
+}
+
+var discID uint32 = 0x860B640B
+
+var discIDCueSheet = CueSheet{
+	Files:  []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	DiscID: &discID,
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+		},
+	},
+}
+
+var barcodeCueSheet = CueSheet{
+	Files:   []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	Barcode: "4006381333931",
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+		},
+	},
+}
+
+var catalogCueSheet = CueSheet{
+	Files:   []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	Catalog: "4006381333931",
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+		},
+	},
+}
+
+var remarksCueSheet = CueSheet{
+	Files:   []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	Remarks: []string{"GENERATOR Some Ripper"},
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+		},
+	},
+}
+
+var commentsCueSheet = CueSheet{
+	Files:    []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	Comments: []string{"ExactAudioCopy v1.6"},
+	Remarks:  []string{"LABEL Sample"},
+	Tracks: []Track{
+		{
+			Number:   1,
+			Type:     "AUDIO",
+			Comments: []string{"Live recording"},
+		},
+	},
+}
+
+var trackRemarksCueSheet = CueSheet{
+	Files:   []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	Date:    "1989",
+	Remarks: []string{`GENERATOR "Some Ripper"`},
+	Tracks: []Track{
+		{
+			Number:    1,
+			Type:      "AUDIO",
+			TrackGain: -7.89,
+			Remarks:   []string{`COMPOSER "Some Composer"`},
+		},
+	},
+}
+
+var multipleFilesCueSheet = CueSheet{
+	Files: []FileEntry{
+		{FileName: "first.wav", Format: "WAVE"},
+		{FileName: "second.wav", Format: "WAVE"},
+	},
+	Tracks: []Track{
+		{Number: 1, Type: "AUDIO"},
+		{Number: 2, Type: "AUDIO", Index01: IndexPoint{Timestamp: 3 * time.Minute}},
+		{Number: 3, Type: "AUDIO", File: 1},
+	},
+}
+
+var multipleIndicesCueSheet = CueSheet{
+	Files: []FileEntry{{FileName: "sample.flac", Format: "WAVE"}},
+	Tracks: []Track{
+		{
+			Number: 1,
+			Type:   "AUDIO",
+			Indices: []IndexPoint{
+				{Timestamp: 30 * time.Second},
+			},
+		},
+		{
+			Number:  2,
+			Type:    "AUDIO",
+			Index00: &IndexPoint{Timestamp: 58 * time.Second},
+			Index01: IndexPoint{Timestamp: time.Minute},
+			Indices: []IndexPoint{
+				{Timestamp: time.Minute + 30*time.Second},
+				{Timestamp: time.Minute + 45*time.Second, Frame: 10},
+			},
+		},
+	},
+}
+
 func TestParseCueSheets(t *testing.T) {
 	tcs := []testCase{
 		{
@@ -78,6 +255,11 @@ func TestParseCueSheets(t *testing.T) {
 			input:       open(t, path.Join("command", "unexpected.cue")),
 			expectedErr: errors.New("unexpected command: UNSUPPORTED"),
 		},
+		{
+			name:        "RepeatedTitle",
+			input:       open(t, path.Join("command", "repeated_title.cue")),
+			expectedErr: errors.New("field already set: Sample Album"),
+		},
 		{
 			name:        "InsufficientLineFields",
 			input:       open(t, path.Join("command", "insufficient.cue")),
@@ -90,22 +272,71 @@ func TestParseCueSheets(t *testing.T) {
 	}
 }
 
+func TestParseTrackTypes(t *testing.T) {
+	types := []TrackType{
+		TrackTypeAudio, TrackTypeCDG,
+		TrackTypeMode12048, TrackTypeMode12352,
+		TrackTypeMode22336, TrackTypeMode22352,
+		TrackTypeCDI2336, TrackTypeCDI2352,
+	}
+	for _, typ := range types {
+		t.Run(string(typ), runTest(testCase{
+			input: strings.NewReader(fmt.Sprintf("FILE sample.bin BINARY\nTRACK 01 %s\nINDEX 01 00:00:00\n", typ)),
+			expected: CueSheet{
+				Files:  []FileEntry{{FileName: "sample.bin", Format: AudioFormatBinary}},
+				Tracks: []Track{{Number: 1, Type: typ}},
+			},
+		}))
+	}
+}
+
+func TestParseTrackNumbers(t *testing.T) {
+	c, err := Parse(open(t, "all.cue"))
+	require.NoError(t, err)
+	require.Equal(t, 1, c.Tracks[0].Number)
+	require.Equal(t, 2, c.Tracks[1].Number)
+}
+
+func TestValidateTrackNumbers(t *testing.T) {
+	c := &CueSheet{
+		Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio},
+			{Number: 3, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}},
+		},
+	}
+	require.ErrorContains(t, c.validate(), "track 2: unexpected track number 3")
+}
+
 func TestParseFileCommand(t *testing.T) {
 	tcs := []testCase{
 		{
-			name:        "RepeatedFileCommand",
-			input:       open(t, path.Join("file", "repeated.cue")),
-			expectedErr: errors.New("field already set: WAVE"),
+			name:     "MultipleFiles",
+			input:    open(t, path.Join("file", "multiple.cue")),
+			expected: multipleFilesCueSheet,
 		},
 		{
 			name:        "InsufficientFileParams",
 			input:       open(t, path.Join("file", "insufficient.cue")),
-			expectedErr: errors.New("expected 2 parameters, got 1"),
+			expectedErr: errors.New("expected at least 2 parameters, got 1"),
 		},
 		{
 			name:        "ExcessiveFileParams",
 			input:       open(t, path.Join("file", "excessive.cue")),
-			expectedErr: errors.New("expected 2 parameters, got 3"),
+			expectedErr: errors.New("expected at most 2 parameters, got 3"),
+		},
+		{
+			name:        "UnquotedFileName",
+			input:       open(t, path.Join("file", "unquoted_name.cue")),
+			expectedErr: errors.New("FILE: expected at most 2 parameters, got 4"),
+		},
+		{
+			name:  "UnknownFileFormat",
+			input: open(t, path.Join("file", "unsupported_format.cue")),
+			expected: CueSheet{
+				Files:  []FileEntry{{FileName: "sample.flac", Format: "FLAC"}},
+				Tracks: []Track{{Number: 1, Type: "AUDIO"}},
+			},
 		},
 		{
 			name:        "EmptyFileName",
@@ -118,17 +349,26 @@ func TestParseFileCommand(t *testing.T) {
 	}
 }
 
+func TestParseRequireKnownFormats(t *testing.T) {
+	_, err := ParseWithOptions(open(t, path.Join("file", "unsupported_format.cue")), ParseOptions{RequireKnownFormats: true})
+	require.ErrorContains(t, err, "unsupported file format: FLAC")
+
+	c, err := ParseWithOptions(open(t, path.Join("file", "multiple.cue")), ParseOptions{RequireKnownFormats: true})
+	require.NoError(t, err)
+	require.Equal(t, multipleFilesCueSheet, *c)
+}
+
 func TestParseTrackCommand(t *testing.T) {
 	tcs := []testCase{
 		{
 			name:        "InsufficientTrackParams",
 			input:       open(t, path.Join("track", "insufficient.cue")),
-			expectedErr: errors.New("expected 2 parameters, got 1"),
+			expectedErr: errors.New("expected at least 2 parameters, got 1"),
 		},
 		{
 			name:        "ExcessiveTrackParams",
 			input:       open(t, path.Join("track", "excessive.cue")),
-			expectedErr: errors.New("expected 2 parameters, got 3"),
+			expectedErr: errors.New("expected at most 2 parameters, got 3"),
 		},
 		{
 			name:        "MissingTracks",
@@ -140,11 +380,51 @@ func TestParseTrackCommand(t *testing.T) {
 			input:       open(t, path.Join("track", "unordered.cue")),
 			expectedErr: errors.New("expected track number 1, got 2"),
 		},
+		{
+			name:  "TrackPerformer",
+			input: open(t, path.Join("track", "performer.cue")),
+			expected: CueSheet{
+				AlbumPerformer: "Various Artists",
+				Files:          []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+				Tracks: []Track{
+					{Number: 1, Type: TrackTypeAudio, Performer: "First Artist"},
+					{Number: 2, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}},
+				},
+			},
+		},
+		{
+			name:        "RepeatedTrackPerformer",
+			input:       open(t, path.Join("track", "repeated_performer.cue")),
+			expectedErr: errors.New("field already set: First Artist"),
+		},
+		{
+			name:  "ISRC",
+			input: open(t, path.Join("track", "isrc.cue")),
+			expected: CueSheet{
+				Files:  []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+				Tracks: []Track{{Number: 1, Type: TrackTypeAudio, ISRC: "USRC17607839"}},
+			},
+		},
+		{
+			name:        "InvalidISRC",
+			input:       open(t, path.Join("track", "invalid_isrc.cue")),
+			expectedErr: errors.New(`invalid ISRC: "usrc17607839"`),
+		},
+		{
+			name:        "ISRCWithoutTrack",
+			input:       open(t, path.Join("track", "isrc_without_track.cue")),
+			expectedErr: errors.New("ISRC: no current track"),
+		},
 		{
 			name:        "NonNumericTrackNumber",
 			input:       open(t, path.Join("track", "non_numeric.cue")),
 			expectedErr: errors.New("failed to parse track number"),
 		},
+		{
+			name:        "UnsupportedTrackType",
+			input:       open(t, path.Join("track", "unsupported_type.cue")),
+			expectedErr: errors.New("unsupported track type: VIDEO"),
+		},
 		{
 			name:        "ExceedsMaxTracks",
 			input:       strings.NewReader(generateExceedsMaxTracks()),
@@ -156,6 +436,40 @@ func TestParseTrackCommand(t *testing.T) {
 	}
 }
 
+func TestValidateTooManyTracks(t *testing.T) {
+	c := &CueSheet{Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}}}
+	for i := range maxTracks + 1 {
+		c.Tracks = append(c.Tracks, Track{Number: i + 1, Type: TrackTypeAudio, Index01: IndexPointFromFrames(i)})
+	}
+	require.ErrorContains(t, c.validate(), "track count 100 exceeds maximum of 99")
+
+	c.Tracks = c.Tracks[:maxTracks]
+	require.NoError(t, c.validate())
+}
+
+func TestParseCatalogCommand(t *testing.T) {
+	tcs := []testCase{
+		{
+			name:     "Catalog",
+			input:    open(t, path.Join("catalog", "valid.cue")),
+			expected: catalogCueSheet,
+		},
+		{
+			name:        "MalformedCatalog",
+			input:       open(t, path.Join("catalog", "malformed.cue")),
+			expectedErr: errors.New(`invalid catalog number, expected 13 digits: "400638133393"`),
+		},
+		{
+			name:        "CatalogCheckDigit",
+			input:       open(t, path.Join("catalog", "check_digit.cue")),
+			expectedErr: errors.New(`invalid catalog number "4006381333932": expected check digit 1`),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, runTest(tc))
+	}
+}
+
 func TestParseIndex(t *testing.T) {
 	tcs := []testCase{
 		{
@@ -186,12 +500,57 @@ func TestParseIndex(t *testing.T) {
 		{
 			name:        "InsufficientIndexParams",
 			input:       open(t, path.Join("index", "insufficient.cue")),
-			expectedErr: errors.New("expected 2 parameters, got 1"),
+			expectedErr: errors.New("expected at least 2 parameters, got 1"),
 		},
 		{
 			name:        "ExcessiveIndexParams",
 			input:       open(t, path.Join("index", "excessive.cue")),
-			expectedErr: errors.New("expected 2 parameters, got 3"),
+			expectedErr: errors.New("expected at most 2 parameters, got 3"),
+		},
+		{
+			name:     "MultipleIndices",
+			input:    open(t, path.Join("index", "multiple.cue")),
+			expected: multipleIndicesCueSheet,
+		},
+		{
+			name:        "SkippedIndex",
+			input:       open(t, path.Join("index", "skipped.cue")),
+			expectedErr: errors.New("expected index number 2, got 3"),
+		},
+		{
+			name:        "OverlappingSubindex",
+			input:       open(t, path.Join("index", "overlapping_subindex.cue")),
+			expectedErr: errors.New("track 1: index 00:05:00 is not after 00:10:00"),
+		},
+		{
+			name:        "OverlappingTrackSubindex",
+			input:       open(t, path.Join("index", "overlapping_track_subindex.cue")),
+			expectedErr: errors.New("overlapping indices in tracks 1 and 2"),
+		},
+		{
+			name:        "Index00AfterIndex01",
+			input:       open(t, path.Join("index", "index00_after_index01.cue")),
+			expectedErr: errors.New("track 2: INDEX 00 must be before INDEX 01"),
+		},
+		{
+			name:        "MissingIndex01",
+			input:       open(t, path.Join("index", "missing_index01.cue")),
+			expectedErr: errors.New("track 2: missing INDEX 01"),
+		},
+		{
+			name:        "IndexWithoutTrack",
+			input:       open(t, path.Join("index", "without_track.cue")),
+			expectedErr: errors.New("INDEX: no current track"),
+		},
+		{
+			name:        "SecondsOverflow",
+			input:       open(t, path.Join("index", "seconds_overflow.cue")),
+			expectedErr: errors.New("seconds out of range [0, 59]: 99"),
+		},
+		{
+			name:        "FramesOverflow",
+			input:       open(t, path.Join("index", "frames_overflow.cue")),
+			expectedErr: errors.New("frames out of range [0, 74]: 99"),
 		},
 	}
 	for _, tc := range tcs {
@@ -199,6 +558,415 @@ func TestParseIndex(t *testing.T) {
 	}
 }
 
+func TestParseRemCommand(t *testing.T) {
+	totalLengthCueSheet := allCueSheet
+	totalLengthCueSheet.TotalLength = &IndexPoint{Timestamp: 2 * time.Minute, Frame: 30}
+
+	tcs := []testCase{
+		{
+			name:     "TotalLength",
+			input:    open(t, path.Join("rem", "total_length.cue")),
+			expected: totalLengthCueSheet,
+		},
+		{
+			name:     "Remarks",
+			input:    open(t, path.Join("rem", "unknown.cue")),
+			expected: remarksCueSheet,
+		},
+		{
+			name:     "Comments",
+			input:    open(t, path.Join("rem", "comments.cue")),
+			expected: commentsCueSheet,
+		},
+		{
+			name:     "TrackRemarks",
+			input:    open(t, path.Join("rem", "track_remarks.cue")),
+			expected: trackRemarksCueSheet,
+		},
+		{
+			name:     "ReplayGain",
+			input:    open(t, path.Join("rem", "replay_gain.cue")),
+			expected: replayGainCueSheet,
+		},
+		{
+			name:        "MalformedReplayGain",
+			input:       open(t, path.Join("rem", "malformed_replay_gain.cue")),
+			expectedErr: errors.New("failed to parse ReplayGain value"),
+		},
+		{
+			name:        "TrackReplayGainWithoutTrack",
+			input:       open(t, path.Join("rem", "track_replay_gain_without_track.cue")),
+			expectedErr: errors.New("REPLAYGAIN_TRACK_GAIN: no current track"),
+		},
+		{
+			name:     "DiscNumber",
+			input:    open(t, path.Join("rem", "disc_number.cue")),
+			expected: discNumberCueSheet,
+		},
+		{
+			name:     "Date",
+			input:    open(t, path.Join("rem", "date.cue")),
+			expected: dateCueSheet,
+		},
+		{
+			name:     "DiscID",
+			input:    open(t, path.Join("rem", "discid.cue")),
+			expected: discIDCueSheet,
+		},
+		{
+			name:        "RepeatedZeroDiscID",
+			input:       open(t, path.Join("rem", "repeated_zero_discid.cue")),
+			expectedErr: errors.New("field already set: 00000000"),
+		},
+		{
+			name:        "ShortDiscID",
+			input:       open(t, path.Join("rem", "short_discid.cue")),
+			expectedErr: errors.New(`invalid disc ID, expected 8 hexadecimal digits: "860B64"`),
+		},
+		{
+			name:     "MusicBrainzDiscID",
+			input:    open(t, path.Join("rem", "musicbrainz_discid.cue")),
+			expected: musicBrainzCueSheet,
+		},
+		{
+			name:     "BarcodeEAN",
+			input:    open(t, path.Join("rem", "barcode_ean.cue")),
+			expected: barcodeCueSheet,
+		},
+		{
+			name:     "BarcodeUPC",
+			input:    open(t, path.Join("rem", "barcode_upc.cue")),
+			expected: barcodeCueSheet,
+		},
+		{
+			name:     "Barcode",
+			input:    open(t, path.Join("rem", "barcode.cue")),
+			expected: barcodeCueSheet,
+		},
+		{
+			name:        "ConflictingBarcodes",
+			input:       open(t, path.Join("rem", "conflicting_barcodes.cue")),
+			expectedErr: errors.New("field already set: 4006381333931"),
+		},
+		{
+			name:        "ShortMusicBrainzDiscID",
+			input:       open(t, path.Join("rem", "short_musicbrainz_discid.cue")),
+			expectedErr: errors.New(`invalid MusicBrainz disc ID: "49HHV7Eb8UKF3aQiNmu1GR8vKTY"`),
+		},
+		{
+			name:        "InvalidMusicBrainzDiscID",
+			input:       open(t, path.Join("rem", "invalid_musicbrainz_discid.cue")),
+			expectedErr: errors.New(`invalid MusicBrainz disc ID: "49HHV7Eb8UKF3aQiNmu1GR8v+TY="`),
+		},
+		{
+			name:        "DiscNumberOutOfRange",
+			input:       open(t, path.Join("rem", "disc_number_out_of_range.cue")),
+			expectedErr: errors.New("disc number 3 exceeds total discs 2"),
+		},
+		{
+			name:        "NonPositiveDiscNumber",
+			input:       open(t, path.Join("rem", "disc_number_zero.cue")),
+			expectedErr: errors.New("expected a positive number, got 0"),
+		},
+		{
+			name:        "NonNumericTotalDiscs",
+			input:       open(t, path.Join("rem", "total_discs_non_numeric.cue")),
+			expectedErr: errors.New("failed to parse number"),
+		},
+		{
+			name:        "RepeatedTotalLength",
+			input:       open(t, path.Join("rem", "repeated_total_length.cue")),
+			expectedErr: errors.New("field already set: 02:00:00"),
+		},
+		{
+			name:        "InvalidTotalLength",
+			input:       open(t, path.Join("rem", "invalid_total_length.cue")),
+			expectedErr: errors.New("error parsing TOTALLENGTH"),
+		},
+		{
+			name:        "TotalLengthBeforeLastTrack",
+			input:       open(t, path.Join("rem", "short_total_length.cue")),
+			expectedErr: errors.New("total length 00:30:00 is not after the last track"),
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, runTest(tc))
+	}
+}
+
+func TestParseWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	_, err := ParseWithOptions(open(t, "all.cue"), ParseOptions{Logger: logger})
+	require.NoError(t, err)
+
+	output := buf.String()
+	require.Contains(t, output, `msg="cue sheet parsed correctly"`)
+	require.Contains(t, output, "lines=9")
+	require.Contains(t, output, "file=sample.flac")
+	require.Contains(t, output, "format=WAVE")
+	require.Contains(t, output, "tracks=2")
+}
+
+func TestParseLenientMode(t *testing.T) {
+	_, err := Parse(open(t, path.Join("command", "unknown.cue")))
+	require.ErrorContains(t, err, "unexpected command: FLAGS")
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c, err := ParseWithOptions(open(t, path.Join("command", "unknown.cue")), ParseOptions{Logger: logger, LenientMode: true})
+	require.NoError(t, err)
+	require.Equal(t, minimalCueSheet, *c)
+
+	output := buf.String()
+	require.Equal(t, 3, strings.Count(output, `msg="skipping unknown command"`))
+	require.Contains(t, output, "command=FLAGS")
+	require.Contains(t, output, "command=EXTENSION")
+	require.Contains(t, output, "command=PREGAP")
+
+	_, err = ParseWithOptions(open(t, path.Join("track", "excessive.cue")), ParseOptions{Logger: logger, LenientMode: true})
+	require.ErrorContains(t, err, "expected at most 2 parameters, got 3")
+}
+
+func TestParseOnUnknownCommand(t *testing.T) {
+	unknown := map[string][]string{}
+	opts := ParseOptions{
+		OnUnknownCommand: func(cmd string, params []string) error {
+			unknown[cmd] = params
+			return nil
+		},
+	}
+	c, err := ParseWithOptions(open(t, path.Join("command", "unknown.cue")), opts)
+	require.NoError(t, err)
+	require.Equal(t, minimalCueSheet, *c)
+	require.Equal(t, map[string][]string{
+		"FLAGS":     {"DCP"},
+		"EXTENSION": {"foo", "bar"},
+		"PREGAP":    {"00:02:00"},
+	}, unknown)
+
+	errUnsupported := errors.New("unsupported command")
+	opts.OnUnknownCommand = func(cmd string, params []string) error {
+		if cmd == "EXTENSION" {
+			return errUnsupported
+		}
+		return nil
+	}
+	_, err = ParseWithOptions(open(t, path.Join("command", "unknown.cue")), opts)
+	require.ErrorIs(t, err, errUnsupported)
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, 3, parseErr.Line)
+}
+
+func TestParseErrorOnUnknownCommands(t *testing.T) {
+	tcs := []struct {
+		name                   string
+		lenientMode            bool
+		errorOnUnknownCommands bool
+		expectedErr            string
+		expectedInvalidErr     string
+	}{
+		{name: "Default", expectedErr: "unexpected command: FLAGS", expectedInvalidErr: "expected at most 1 parameters, got 2"},
+		{name: "LenientMode", lenientMode: true, expectedInvalidErr: "expected at most 1 parameters, got 2"},
+		{name: "ErrorOnUnknownCommands", errorOnUnknownCommands: true, expectedErr: "unexpected command: FLAGS", expectedInvalidErr: "expected at most 1 parameters, got 2"},
+		{name: "LenientModeAndErrorOnUnknownCommands", lenientMode: true, errorOnUnknownCommands: true, expectedErr: "unexpected command: FLAGS"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := ParseOptions{LenientMode: tc.lenientMode, ErrorOnUnknownCommands: tc.errorOnUnknownCommands}
+			c, err := ParseWithOptions(open(t, path.Join("command", "unknown.cue")), opts)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, minimalCueSheet, *c)
+			}
+
+			// Known commands with invalid parameters are only skipped with both options.
+			c, err = ParseWithOptions(open(t, path.Join("command", "invalid.cue")), opts)
+			if tc.expectedInvalidErr != "" {
+				require.ErrorContains(t, err, tc.expectedInvalidErr)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, minimalCueSheet, *c)
+			}
+		})
+	}
+
+	var unknown []string
+	opts := ParseOptions{
+		ErrorOnUnknownCommands: true,
+		OnUnknownCommand: func(cmd string, _ []string) error {
+			unknown = append(unknown, cmd)
+			return nil
+		},
+	}
+	_, err := ParseWithOptions(open(t, path.Join("command", "unknown.cue")), opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"FLAGS", "EXTENSION", "PREGAP"}, unknown)
+}
+
+func TestParseErrorOnUnknownCommandsInvalidTrack(t *testing.T) {
+	opts := ParseOptions{LenientMode: true, ErrorOnUnknownCommands: true}
+	const input = `FILE sample.flac WAVE
+TRACK 01 AUDIO
+  INDEX 01 00:00:00
+TRACK XX AUDIO
+  TITLE Skipped
+  INDEX 01 00:10:00
+TRACK 03 AUDIO
+  INDEX 01 00:20:00
+`
+	// The lines of the skipped tracks are not attached to the previous track.
+	c, err := ParseWithOptions(strings.NewReader(input), opts)
+	require.NoError(t, err)
+	require.Equal(t, TrackList{{Number: 1, Type: TrackTypeAudio}}, c.Tracks)
+
+	const recovered = `FILE sample.flac WAVE
+TRACK 01 AUDIO
+  INDEX 01 00:00:00
+TRACK 02 VIDEO
+  PERFORMER Skipped
+  INDEX 01 00:10:00
+TRACK 02 AUDIO
+  TITLE Second Track
+  INDEX 01 00:20:00
+`
+	c, err = ParseWithOptions(strings.NewReader(recovered), opts)
+	require.NoError(t, err)
+	require.Equal(t, TrackList{
+		{Number: 1, Type: TrackTypeAudio},
+		{Number: 2, Type: TrackTypeAudio, Title: "Second Track", Index01: IndexPoint{Timestamp: 20 * time.Second}},
+	}, c.Tracks)
+}
+
+func TestParseAllowZeroFirstTrackIndex(t *testing.T) {
+	_, err := Parse(open(t, path.Join("index", "zero_first_track.cue")))
+	require.ErrorContains(t, err, "track 1: INDEX 00 must be before INDEX 01")
+
+	c, err := ParseWithOptions(open(t, path.Join("index", "zero_first_track.cue")), ParseOptions{AllowZeroFirstTrackIndex: true})
+	require.NoError(t, err)
+	require.Equal(t, TrackList{
+		{Number: 1, Type: TrackTypeAudio},
+		{Number: 2, Type: TrackTypeAudio, Index00: &IndexPoint{Timestamp: 58 * time.Second}, Index01: IndexPoint{Timestamp: time.Minute}},
+	}, c.Tracks)
+
+	_, err = ParseWithOptions(open(t, path.Join("index", "index00_after_index01.cue")), ParseOptions{AllowZeroFirstTrackIndex: true})
+	require.ErrorContains(t, err, "track 2: INDEX 00 must be before INDEX 01")
+}
+
+func TestParseAllowDuplicateTracks(t *testing.T) {
+	_, err := Parse(open(t, path.Join("track", "repeated.cue")))
+	require.ErrorContains(t, err, "expected track number 2, got 1")
+
+	c, err := ParseWithOptions(open(t, path.Join("track", "repeated.cue")), ParseOptions{AllowDuplicateTracks: true})
+	require.NoError(t, err)
+	require.Equal(t, TrackList{
+		{Number: 1, Type: TrackTypeAudio},
+		{Number: 2, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}},
+		{Number: 3, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+	}, c.Tracks)
+
+	_, err = ParseWithOptions(open(t, path.Join("track", "unordered.cue")), ParseOptions{AllowDuplicateTracks: true})
+	require.ErrorContains(t, err, "expected track number 1, got 2")
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse(open(t, path.Join("track", "unordered.cue")))
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, 2, parseErr.Line)
+	require.Equal(t, "TRACK 02 AUDIO", parseErr.Text)
+	require.ErrorContains(t, parseErr.Err, "expected track number 1, got 2")
+}
+
+func TestParseErrorByteOffset(t *testing.T) {
+	input := "FILE sample.flac WAVE\r\n\n  TRACK 02 AUDIO\n"
+
+	_, err := Parse(strings.NewReader(input))
+	var parseErr *ParseError
+	require.ErrorAs(t, err, &parseErr)
+	require.Zero(t, parseErr.ByteOffset)
+
+	_, err = ParseWithOptions(strings.NewReader(input), ParseOptions{TrackByteOffsets: true})
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, 3, parseErr.Line)
+	require.Equal(t, int64(24), parseErr.ByteOffset)
+	require.Equal(t, "  TRACK 02 AUDIO", input[parseErr.ByteOffset:len(input)-1])
+}
+
+func TestParseMaxLineLength(t *testing.T) {
+	cueSheet := func(titleLength int) io.Reader {
+		title := "TITLE " + strings.Repeat("a", titleLength-len("TITLE "))
+		return strings.NewReader("FILE sample.flac WAVE\r\n" + title + "\r\nTRACK 01 AUDIO\r\nINDEX 01 00:00:00\r\n")
+	}
+	const maxLineLength = 64 << 10
+
+	_, err := ParseWithOptions(cueSheet(maxLineLength), ParseOptions{MaxLineLength: maxLineLength})
+	require.NoError(t, err)
+
+	for name, opts := range map[string]ParseOptions{
+		"Option":  {MaxLineLength: maxLineLength},
+		"Default": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseWithOptions(cueSheet(2<<20), opts)
+			var parseErr *ParseError
+			require.ErrorAs(t, err, &parseErr)
+			require.Equal(t, 2, parseErr.Line)
+			require.ErrorIs(t, err, bufio.ErrTooLong)
+		})
+	}
+
+	// A 128 KiB line is over the default buffer size of bufio.Scanner, but not over the default limit.
+	_, err = Parse(cueSheet(128 << 10))
+	require.NoError(t, err)
+	_, err = ParseWithOptions(cueSheet(128<<10), ParseOptions{MaxLineLength: maxLineLength})
+	require.ErrorIs(t, err, bufio.ErrTooLong)
+	require.ErrorContains(t, err, "line 2:")
+}
+
+func TestParseSkipValidation(t *testing.T) {
+	_, err := Parse(open(t, "empty.cue"))
+	require.ErrorContains(t, err, "missing file name")
+
+	c, err := ParseWithOptions(open(t, "empty.cue"), ParseOptions{SkipValidation: true})
+	require.NoError(t, err)
+	require.Empty(t, c.Files)
+	require.Empty(t, c.Tracks)
+
+	_, err = ParseWithOptions(open(t, path.Join("track", "unordered.cue")), ParseOptions{SkipValidation: true})
+	require.ErrorContains(t, err, "expected track number 1, got 2")
+}
+
+func TestParseStrictOrdering(t *testing.T) {
+	strict := ParseOptions{StrictOrdering: true}
+	tcs := []struct {
+		name        string
+		file        string
+		expectedErr string
+	}{
+		{name: "AlbumCommandsBeforeFile", file: path.Join("command", "strict_ordering.cue")},
+		{name: "AlbumCommandsAfterFile", file: "all.cue", expectedErr: "PERFORMER must come before FILE"},
+		{name: "TrackBeforeFile", file: path.Join("track", "before_file.cue"), expectedErr: "TRACK must come after FILE"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse(open(t, tc.file))
+			require.NoError(t, err)
+
+			_, err = ParseWithOptions(open(t, tc.file), strict)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
 func runTest(tc testCase) func(t *testing.T) {
 	return func(t *testing.T) {
 		cueSheet, err := Parse(tc.input)
@@ -229,3 +997,95 @@ func generateExceedsMaxTracks() string {
 	}
 	return cueSheet
 }
+
+func TestAppendFields(t *testing.T) {
+	lines := []string{"", "   ", "FILE", "\tTRACK  01\tAUDIO  ", "TITLE \"Caf\u00e9\u00a0Tacvba\"\u2003x", "\xffREM \xfe"}
+	err := fs.WalkDir(testdataFS, "testdata", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".cue" {
+			return err
+		}
+		data, err := testdataFS.ReadFile(p)
+		lines = append(lines, strings.Split(string(data), "\n")...)
+		return err
+	})
+	require.NoError(t, err)
+
+	var fields []string
+	for _, line := range lines {
+		fields = appendFields(fields[:0], line)
+		if expected := strings.Fields(line); len(expected) > 0 {
+			require.Equal(t, expected, fields, "line %q", line)
+		} else {
+			require.Empty(t, fields, "line %q", line)
+		}
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	err := fs.WalkDir(testdataFS, "testdata", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".cue" {
+			return err
+		}
+		data, err := testdataFS.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		f.Add(data)
+		return nil
+	})
+	require.NoError(f, err)
+
+	opts := ParseOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c, err := ParseWithOptions(bytes.NewReader(data), opts)
+		if err != nil {
+			return
+		}
+		var buf bytes.Buffer
+		require.NoError(t, WriteCueSheet(c, &buf))
+		_, err = ParseWithOptions(&buf, opts)
+		require.NoError(t, err, "re-parsing:\n%s", buf.String())
+	})
+}
+
+// generate99Tracks returns a cue sheet with the maximum number of tracks, each with a title,
+// a performer, an ISRC, a pregap and an INDEX 01.
+func generate99Tracks() string {
+	var sb strings.Builder
+	sb.WriteString("REM GENERATOR \"Some Ripper\"\nPERFORMER \"Sample Album Artist\"\nTITLE \"Sample Album\"\nFILE \"sample.flac\" WAVE\n")
+	for i := range maxTracks {
+		fmt.Fprintf(&sb, "  TRACK %02d AUDIO\n", i+1)
+		fmt.Fprintf(&sb, "    TITLE \"Track Title %d\"\n", i+1)
+		fmt.Fprintf(&sb, "    PERFORMER \"Track Artist %d\"\n", i+1)
+		fmt.Fprintf(&sb, "    ISRC USRC176%05d\n", i+1)
+		fmt.Fprintf(&sb, "    INDEX 00 %s\n", IndexPointFromFrames(i*framesPerSecond*60))
+		fmt.Fprintf(&sb, "    INDEX 01 %s\n", IndexPointFromFrames(i*framesPerSecond*60+2*framesPerSecond))
+	}
+	return sb.String()
+}
+
+// The benchmarks discard the log records, so that only the parser is measured.
+var benchmarkOptions = ParseOptions{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+func BenchmarkParse99Tracks(b *testing.B) {
+	data := generate99Tracks()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if _, err := ParseWithOptions(strings.NewReader(data), benchmarkOptions); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkWrite99Tracks(b *testing.B) {
+	c, err := ParseWithOptions(strings.NewReader(generate99Tracks()), benchmarkOptions)
+	require.NoError(b, err)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for range b.N {
+		if err := WriteCueSheet(c, io.Discard); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
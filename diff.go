@@ -0,0 +1,53 @@
+package cuesheetgo
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Diff returns a human-readable description of every field that differs between c and other,
+// such as "AlbumTitle: 'Old' → 'New'" or "Track 2 Title: 'Foo' → 'Bar'".
+// Tracks present in only one of the cue sheets are reported as added or removed.
+// Album fields come first, followed by the tracks in order.
+func (c *CueSheet) Diff(other *CueSheet) []string {
+	diffs := diffFields(nil, "", reflect.ValueOf(*c), reflect.ValueOf(*other))
+	for i := range max(len(c.Tracks), len(other.Tracks)) {
+		switch {
+		case i >= len(other.Tracks):
+			diffs = append(diffs, fmt.Sprintf("Track %d: removed", i+1))
+		case i >= len(c.Tracks):
+			diffs = append(diffs, fmt.Sprintf("Track %d: added", i+1))
+		default:
+			prefix := fmt.Sprintf("Track %d ", i+1)
+			diffs = diffFields(diffs, prefix, reflect.ValueOf(c.Tracks[i]), reflect.ValueOf(other.Tracks[i]))
+		}
+	}
+	return diffs
+}
+
+// diffFields appends the differences between the fields of the structs a and b to diffs.
+// Nested tracks are skipped, they are compared one by one by Diff.
+func diffFields(diffs []string, prefix string, a, b reflect.Value) []string {
+	for i := range a.NumField() {
+		name := a.Type().Field(i).Name
+		if name == "Tracks" {
+			continue
+		}
+		oldValue, newValue := formatField(a.Field(i)), formatField(b.Field(i))
+		if oldValue != newValue {
+			diffs = append(diffs, fmt.Sprintf("%s%s: '%s' → '%s'", prefix, name, oldValue, newValue))
+		}
+	}
+	return diffs
+}
+
+// formatField formats a field value, dereferencing pointers so that nil is shown as empty.
+func formatField(v reflect.Value) string {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,52 @@
+package cuesheetgo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("NoDiff", func(t *testing.T) {
+		other := allCueSheet
+		require.Empty(t, allCueSheet.Diff(&other))
+	})
+
+	t.Run("SingleField", func(t *testing.T) {
+		other := allCueSheet
+		other.AlbumTitle = "New Album"
+		require.Equal(t, []string{"AlbumTitle: 'Sample Album' → 'New Album'"}, allCueSheet.Diff(&other))
+	})
+
+	t.Run("TrackFields", func(t *testing.T) {
+		other := allCueSheet
+		other.Tracks = []Track{
+			allCueSheet.Tracks[0],
+			{
+				Number:  2,
+				Type:    TrackTypeAudio,
+				Title:   "Other Track",
+				Index00: &IndexPoint{Timestamp: 50 * time.Second},
+				Index01: IndexPoint{Timestamp: time.Minute, Frame: 1},
+			},
+		}
+		require.Equal(t, []string{
+			"Track 2 Title: 'Second Track' → 'Other Track'",
+			"Track 2 Index00: '' → '00:50:00'",
+			"Track 2 Index01: '01:00:00' → '01:00:01'",
+		}, allCueSheet.Diff(&other))
+	})
+
+	t.Run("TrackAdded", func(t *testing.T) {
+		other := allCueSheet
+		other.Tracks = append(append([]Track{}, allCueSheet.Tracks...), Track{Type: TrackTypeAudio})
+		require.Equal(t, []string{"Track 3: added"}, allCueSheet.Diff(&other))
+	})
+
+	t.Run("TrackRemoved", func(t *testing.T) {
+		other := allCueSheet
+		other.Tracks = allCueSheet.Tracks[:1]
+		require.Equal(t, []string{"Track 2: removed"}, allCueSheet.Diff(&other))
+	})
+}
@@ -0,0 +1,461 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrTrackNotFound is returned when a track number does not match any track of the cue sheet.
+	ErrTrackNotFound = errors.New("track not found")
+	// ErrValidationFailed is returned when an edit would leave the tracks of the cue sheet invalid.
+	ErrValidationFailed = errors.New("validation failed")
+)
+
+// ShiftTime moves every index point, and the total length when known, by offset.
+// In a cue sheet with several files, the index points of every file are moved.
+// The offset is truncated to whole frames. It returns an error without modifying
+// the cue sheet if any index point would become negative or exceed 99:59:74,
+// or if the shifted tracks are invalid.
+func (c *CueSheet) ShiftTime(offset time.Duration) error {
+	frames := DurationToFrames(offset)
+	shifted := c.Clone()
+	err := shifted.shiftIndexPoints(func(idx IndexPoint) (IndexPoint, error) {
+		result := idx.AbsoluteFrames() + frames
+		if result < 0 {
+			return IndexPoint{}, fmt.Errorf("shifting %s by %s results in a negative index", idx, offset)
+		}
+		if result > maxIndexPoint.AbsoluteFrames() {
+			return IndexPoint{}, fmt.Errorf("shifting %s by %s results in an index after %s", idx, offset, maxIndexPoint)
+		}
+		return IndexPointFromFrames(result), nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := shifted.validateTracks(); err != nil {
+		return err
+	}
+	c.Tracks = shifted.Tracks
+	c.TotalLength = shifted.TotalLength
+	return nil
+}
+
+// ApplyOffset adds offset to every index point, and to the total length when known.
+func (c *CueSheet) ApplyOffset(offset IndexPoint) {
+	// Adding an index point never fails.
+	_ = c.shiftIndexPoints(func(idx IndexPoint) (IndexPoint, error) {
+		return idx.AddIndexPoint(offset), nil
+	})
+}
+
+// SubtractOffset subtracts offset from every index point, and from the total length when
+// known, such as a constant silence encoded before the first track. It returns an error
+// without modifying the cue sheet if any index point would become negative.
+func (c *CueSheet) SubtractOffset(offset IndexPoint) error {
+	return c.shiftIndexPoints(func(idx IndexPoint) (IndexPoint, error) {
+		frames := idx.AbsoluteFrames() - offset.AbsoluteFrames()
+		if frames < 0 {
+			return IndexPoint{}, fmt.Errorf("subtracting %s from %s results in a negative index", offset, idx)
+		}
+		return IndexPointFromFrames(frames), nil
+	})
+}
+
+// shiftIndexPoints replaces every index point, and the total length when known, by its
+// result through shift. The cue sheet is left unchanged when shift returns an error.
+func (c *CueSheet) shiftIndexPoints(shift func(IndexPoint) (IndexPoint, error)) error {
+	tracks := make([]Track, len(c.Tracks))
+	for i, track := range c.Tracks {
+		if track.Index00 != nil {
+			index00, err := shift(*track.Index00)
+			if err != nil {
+				return fmt.Errorf("track %d: %w", i+1, err)
+			}
+			track.Index00 = &index00
+		}
+		index01, err := shift(track.Index01)
+		if err != nil {
+			return fmt.Errorf("track %d: %w", i+1, err)
+		}
+		track.Index01 = index01
+		if track.Indices != nil {
+			indices := make([]IndexPoint, len(track.Indices))
+			for j, idx := range track.Indices {
+				if indices[j], err = shift(idx); err != nil {
+					return fmt.Errorf("track %d: %w", i+1, err)
+				}
+			}
+			track.Indices = indices
+		}
+		tracks[i] = track
+	}
+
+	var totalLength *IndexPoint
+	if c.TotalLength != nil {
+		shifted, err := shift(*c.TotalLength)
+		if err != nil {
+			return fmt.Errorf("total length: %w", err)
+		}
+		totalLength = &shifted
+	}
+
+	c.Tracks = tracks
+	c.TotalLength = totalLength
+	return nil
+}
+
+// SortTracks sorts the tracks in place by their file and INDEX 01 position and renumbers them.
+func (c *CueSheet) SortTracks() {
+	slices.SortStableFunc(c.Tracks, compareTracks)
+	c.RenumberTracks()
+}
+
+// IsSorted reports whether the tracks are ordered by their file and INDEX 01 position.
+func (c *CueSheet) IsSorted() bool {
+	return slices.IsSortedFunc(c.Tracks, compareTracks)
+}
+
+// compareTracks orders the tracks by file, then by INDEX 01 since index points restart in every file.
+func compareTracks(a, b Track) int {
+	if a.File != b.File {
+		return a.File - b.File
+	}
+	return a.Index01.AbsoluteFrames() - b.Index01.AbsoluteFrames()
+}
+
+// AddTrack appends a copy of t as the last track of the cue sheet.
+// The track must start after the end of the current last track.
+func (c *CueSheet) AddTrack(t *Track) error {
+	return c.InsertTrack(len(c.Tracks)+1, t)
+}
+
+// InsertTrack inserts a copy of t so that it becomes the track with the 1-based number n.
+// The following tracks are renumbered. The track must start after the end of its predecessor
+// and end before the start of its successor, otherwise the cue sheet is left unchanged.
+func (c *CueSheet) InsertTrack(n int, t *Track) error {
+	if n < 1 || n > len(c.Tracks)+1 {
+		return fmt.Errorf("track position %d out of range [1, %d]", n, len(c.Tracks)+1)
+	}
+	if len(c.Tracks) >= maxTracks {
+		return fmt.Errorf("cannot have more than %d tracks", maxTracks)
+	}
+	if !t.Type.IsValid() {
+		return fmt.Errorf("unsupported track type: %s", t.Type)
+	}
+	candidate := c.Clone()
+	candidate.Tracks = slices.Insert(candidate.Tracks, n-1, t.clone())
+	candidate.RenumberTracks()
+	if err := candidate.validateTracks(); err != nil {
+		return fmt.Errorf("invalid track: %w", err)
+	}
+	// A cue sheet being built may not have its files yet.
+	if len(candidate.Files) > 0 {
+		if err := candidate.validateTrackFiles(); err != nil {
+			return fmt.Errorf("invalid track: %w", err)
+		}
+	}
+	c.Tracks = candidate.Tracks
+	return nil
+}
+
+// RemoveTrack removes the track with the 1-based number n and returns it.
+// The following tracks are renumbered. If the remaining tracks overlap,
+// the cue sheet is left unchanged.
+func (c *CueSheet) RemoveTrack(n int) (*Track, error) {
+	if n < 1 || n > len(c.Tracks) {
+		return nil, fmt.Errorf("%w: %d", ErrTrackNotFound, n)
+	}
+	removed := c.Tracks[n-1]
+	candidate := &CueSheet{Tracks: slices.Delete(slices.Clone(c.Tracks), n-1, n)}
+	candidate.RenumberTracks()
+	if err := candidate.validateTracks(); err != nil {
+		return nil, fmt.Errorf("invalid tracks after removal: %w", err)
+	}
+	c.Tracks = candidate.Tracks
+	return &removed, nil
+}
+
+// SwapTracks swaps the tracks at the 1-based positions i and j and renumbers them.
+// The index points are not changed, so the swap must put the tracks in the order
+// of their index points, otherwise it returns an error wrapping ErrValidationFailed
+// and the cue sheet is left unchanged.
+func (c *CueSheet) SwapTracks(i, j int) error {
+	for _, n := range []int{i, j} {
+		if n < 1 || n > len(c.Tracks) {
+			return fmt.Errorf("%w: %d", ErrTrackNotFound, n)
+		}
+	}
+	candidate := &CueSheet{Tracks: slices.Clone(c.Tracks)}
+	candidate.Tracks[i-1], candidate.Tracks[j-1] = candidate.Tracks[j-1], candidate.Tracks[i-1]
+	candidate.RenumberTracks()
+	if err := candidate.validateTracks(); err != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, err)
+	}
+	c.Tracks = candidate.Tracks
+	return nil
+}
+
+// SetTrackTitle sets the title of the track with the 1-based number n.
+// The title must not be empty.
+func (c *CueSheet) SetTrackTitle(n int, title string) error {
+	track, ok := c.Track(n)
+	if !ok {
+		return fmt.Errorf("%w: %d", ErrTrackNotFound, n)
+	}
+	return setNonEmpty(&track.Title, "title", title)
+}
+
+// SetAlbumTitle sets the title of the album. The title must not be empty.
+func (c *CueSheet) SetAlbumTitle(title string) error {
+	return setNonEmpty(&c.AlbumTitle, "title", title)
+}
+
+// SetAlbumPerformer sets the performer of the album. The performer must not be empty.
+func (c *CueSheet) SetAlbumPerformer(performer string) error {
+	return setNonEmpty(&c.AlbumPerformer, "performer", performer)
+}
+
+func setNonEmpty(field *string, name, value string) error {
+	if value == "" {
+		return fmt.Errorf("empty %s", name)
+	}
+	*field = value
+	return nil
+}
+
+// AddRemark appends remark to the album remarks, written as "REM <remark>".
+// The remark must not be empty nor span several lines, and must not start with a REM key
+// parsed into a field, such as DATE, so that it is read back as a remark.
+func (c *CueSheet) AddRemark(remark string) error {
+	fields := strings.Fields(remark)
+	if len(fields) == 0 {
+		return errors.New("empty remark")
+	}
+	if strings.ContainsAny(remark, "\r\n") {
+		return fmt.Errorf("remark spans several lines: %q", remark)
+	}
+	if remKeys[fields[0]] {
+		return fmt.Errorf("remark starts with the REM key %s: %q", fields[0], remark)
+	}
+	c.Remarks = append(c.Remarks, remark)
+	return nil
+}
+
+// ClearRemarks removes all the album remarks.
+func (c *CueSheet) ClearRemarks() {
+	c.Remarks = nil
+}
+
+// RemarkCount returns the number of album remarks.
+func (c *CueSheet) RemarkCount() int {
+	return len(c.Remarks)
+}
+
+// Merge returns a new cue sheet with the tracks of c followed by the renumbered tracks of other.
+// The files of other are added after the files of c, but for the files they already share, so that
+// index points are kept as they are. The album metadata is taken from c. It is the inverse of Split.
+// It returns an error if the merged cue sheet is invalid.
+func (c *CueSheet) Merge(other *CueSheet) (*CueSheet, error) {
+	if c.Format() != other.Format() {
+		return nil, fmt.Errorf("incompatible formats: %s and %s", c.Format(), other.Format())
+	}
+	if n := len(c.Tracks) + len(other.Tracks); n > maxTracks {
+		return nil, fmt.Errorf("merged cue sheet has %d tracks, cannot have more than %d", n, maxTracks)
+	}
+
+	merged := c.withoutTracks()
+	// files maps the index of every file of other to its index in the merged cue sheet.
+	files := make([]int, len(other.Files))
+	for i, file := range other.Files {
+		files[i] = slices.Index(merged.Files, file)
+		if files[i] == -1 {
+			files[i] = len(merged.Files)
+			merged.Files = append(merged.Files, file)
+		}
+	}
+	for _, track := range c.Tracks {
+		merged.Tracks = append(merged.Tracks, track.clone())
+	}
+	for _, track := range other.Tracks {
+		track = track.clone()
+		if track.File >= 0 && track.File < len(files) {
+			track.File = files[track.File]
+		}
+		merged.Tracks = append(merged.Tracks, track)
+	}
+	merged.RenumberTracks()
+	if err := merged.validate(); err != nil {
+		return nil, fmt.Errorf("invalid merged cue sheet: %w", err)
+	}
+	return merged, nil
+}
+
+// Split returns two new cue sheets, the first with tracks 1 to n and the second with the rest.
+// Both keep the file, format and album metadata of c. It is the inverse of Merge.
+func (c *CueSheet) Split(n int) (*CueSheet, *CueSheet, error) {
+	if n < 1 || n > len(c.Tracks) {
+		return nil, nil, fmt.Errorf("%w: %d", ErrTrackNotFound, n)
+	}
+	first, second := c.withoutTracks(), c.withoutTracks()
+	for i, track := range c.Tracks {
+		if i < n {
+			first.Tracks = append(first.Tracks, track.clone())
+		} else {
+			second.Tracks = append(second.Tracks, track.clone())
+		}
+	}
+	second.RenumberTracks()
+	return first, second, nil
+}
+
+// Flatten removes every INDEX 00 at the same position as the INDEX 01 of the previous track.
+// Such a pregap leaves the previous track empty, and validation reports it as overlapping it.
+// Once it is removed, the audio between the INDEX 01 of the previous track and the INDEX 01
+// of this track is assigned to the previous track.
+func (c *CueSheet) Flatten() {
+	for i := 1; i < len(c.Tracks); i++ {
+		if index00 := c.Tracks[i].Index00; index00 != nil && c.Tracks[i].File == c.Tracks[i-1].File && *index00 == c.Tracks[i-1].Index01 {
+			c.Tracks[i].Index00 = nil
+		}
+	}
+}
+
+// Normalize canonicalizes in place the values that tools write inconsistently: it uppercases
+// the audio formats and the track types, trims the surrounding whitespace of the album title,
+// performer and date and of the track titles, and removes the blank album and track remarks.
+// It returns an error, leaving the cue sheet unchanged, if the normalized cue sheet is invalid.
+func (c *CueSheet) Normalize() error {
+	normalized := c.Clone()
+	normalized.AlbumTitle = strings.TrimSpace(normalized.AlbumTitle)
+	normalized.AlbumPerformer = strings.TrimSpace(normalized.AlbumPerformer)
+	normalized.Date = strings.TrimSpace(normalized.Date)
+	normalized.Remarks = withoutBlankRemarks(normalized.Remarks)
+	for i := range normalized.Files {
+		file := &normalized.Files[i]
+		file.Format = AudioFormat(strings.ToUpper(string(file.Format)))
+	}
+	for i := range normalized.Tracks {
+		track := &normalized.Tracks[i]
+		track.Type = TrackType(strings.ToUpper(string(track.Type)))
+		if !track.Type.IsValid() {
+			return fmt.Errorf("track %d: unsupported track type: %s", i+1, track.Type)
+		}
+		track.Title = strings.TrimSpace(track.Title)
+		track.Remarks = withoutBlankRemarks(track.Remarks)
+	}
+	if err := normalized.validate(); err != nil {
+		return fmt.Errorf("invalid normalized cue sheet: %w", err)
+	}
+	*c = *normalized
+	return nil
+}
+
+// withoutBlankRemarks removes in place the remarks made only of whitespace, nil if none is left.
+func withoutBlankRemarks(remarks []string) []string {
+	remarks = slices.DeleteFunc(remarks, func(remark string) bool { return strings.TrimSpace(remark) == "" })
+	if len(remarks) == 0 {
+		return nil
+	}
+	return remarks
+}
+
+// RenumberTracks sets the number of every track to its 1-based position,
+// after the tracks have been modified directly.
+func (c *CueSheet) RenumberTracks() {
+	for i := range c.Tracks {
+		c.Tracks[i].Number = i + 1
+	}
+}
+
+// NumbersAreSequential reports whether every track is numbered by its 1-based position.
+func (c *CueSheet) NumbersAreSequential() bool {
+	for i, track := range c.Tracks {
+		if track.Number != i+1 {
+			return false
+		}
+	}
+	return true
+}
+
+// withoutTracks returns a new cue sheet with the file and album metadata of c and no tracks.
+func (c *CueSheet) withoutTracks() *CueSheet {
+	return &CueSheet{
+		AlbumPerformer: c.AlbumPerformer,
+		AlbumTitle:     c.AlbumTitle,
+		Date:           c.Date,
+		Files:          slices.Clone(c.Files),
+		Tracks:         []Track{},
+	}
+}
+
+// Clone returns a deep copy of the cue sheet.
+func (c *CueSheet) Clone() *CueSheet {
+	clone := *c
+	if c.Tracks != nil {
+		clone.Tracks = make([]Track, len(c.Tracks))
+		for i, track := range c.Tracks {
+			clone.Tracks[i] = track.clone()
+		}
+	}
+	if c.TotalLength != nil {
+		totalLength := *c.TotalLength
+		clone.TotalLength = &totalLength
+	}
+	if c.DiscID != nil {
+		discID := *c.DiscID
+		clone.DiscID = &discID
+	}
+	clone.Files = slices.Clone(c.Files)
+	clone.Comments = slices.Clone(c.Comments)
+	clone.Remarks = slices.Clone(c.Remarks)
+	return &clone
+}
+
+// WithoutRemarks returns a deep copy of the cue sheet without any REM comment or remark,
+// both at album and track level.
+func (c *CueSheet) WithoutRemarks() *CueSheet {
+	clone := c.Clone()
+	clone.Comments = nil
+	clone.Remarks = nil
+	for i := range clone.Tracks {
+		clone.Tracks[i].Comments = nil
+		clone.Tracks[i].Remarks = nil
+	}
+	return clone
+}
+
+// Anonymize returns a deep copy of the cue sheet without album and track titles and performers.
+// The file, the index points and the other metadata are kept.
+func (c *CueSheet) Anonymize() *CueSheet {
+	clone := c.Clone()
+	clone.AlbumPerformer = ""
+	clone.AlbumTitle = ""
+	for i := range clone.Tracks {
+		clone.Tracks[i].Title = ""
+		clone.Tracks[i].Performer = ""
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the track, so that its index points, comments
+// and remarks can be edited without modifying the cue sheet.
+func (t *Track) Clone() *Track {
+	clone := t.clone()
+	return &clone
+}
+
+// clone returns a deep copy of the track.
+func (t Track) clone() Track {
+	if t.Index00 != nil {
+		index00 := *t.Index00
+		t.Index00 = &index00
+	}
+	t.Indices = slices.Clone(t.Indices)
+	t.Comments = slices.Clone(t.Comments)
+	t.Remarks = slices.Clone(t.Remarks)
+	return t
+}
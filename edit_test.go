@@ -0,0 +1,698 @@
+package cuesheetgo
+
+import (
+	"bytes"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShiftTime(t *testing.T) {
+	newCueSheet := func() *CueSheet {
+		return &CueSheet{
+			Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+			Tracks: []Track{
+				{Number: 1, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Second}},
+				{
+					Number:  2,
+					Type:    TrackTypeAudio,
+					Index00: &IndexPoint{Timestamp: 58 * time.Second, Frame: 10},
+					Index01: IndexPoint{Timestamp: time.Minute},
+					Indices: []IndexPoint{{Timestamp: time.Minute + 30*time.Second}},
+				},
+			},
+		}
+	}
+
+	t.Run("Positive", func(t *testing.T) {
+		c := newCueSheet()
+		require.NoError(t, c.ShiftTime(time.Second+40*time.Millisecond))
+		require.Equal(t, IndexPoint{Timestamp: 3 * time.Second, Frame: 3}, c.Tracks[0].Index01)
+		require.Equal(t, &IndexPoint{Timestamp: 59 * time.Second, Frame: 13}, c.Tracks[1].Index00)
+		require.Equal(t, IndexPoint{Timestamp: time.Minute + time.Second, Frame: 3}, c.Tracks[1].Index01)
+		require.Equal(t, []IndexPoint{{Timestamp: time.Minute + 31*time.Second, Frame: 3}}, c.Tracks[1].Indices)
+	})
+
+	t.Run("NegativeWithinBounds", func(t *testing.T) {
+		c := newCueSheet()
+		require.NoError(t, c.ShiftTime(-2*time.Second))
+		require.Equal(t, IndexPoint{}, c.Tracks[0].Index01)
+		require.Equal(t, &IndexPoint{Timestamp: 56 * time.Second, Frame: 10}, c.Tracks[1].Index00)
+		require.Equal(t, IndexPoint{Timestamp: 58 * time.Second}, c.Tracks[1].Index01)
+	})
+
+	t.Run("NegativeTimestamp", func(t *testing.T) {
+		c := newCueSheet()
+		err := c.ShiftTime(-3 * time.Second)
+		require.ErrorContains(t, err, "track 1: shifting 00:02:00 by -3s results in a negative index")
+		require.Equal(t, newCueSheet(), c)
+	})
+
+	t.Run("PastMaxIndexPoint", func(t *testing.T) {
+		c := newCueSheet()
+		err := c.ShiftTime(99 * time.Minute)
+		require.ErrorContains(t, err, "track 2: shifting 01:00:00 by 1h39m0s results in an index after 99:59:74")
+		require.Equal(t, newCueSheet(), c)
+	})
+
+	t.Run("InvalidTracks", func(t *testing.T) {
+		c := newCueSheet()
+		c.Tracks[1].Index01 = IndexPoint{Timestamp: time.Second}
+		invalid := c.Clone()
+		require.ErrorContains(t, c.ShiftTime(time.Minute), "track 2: INDEX 00 must be before INDEX 01")
+		require.Equal(t, invalid, c)
+	})
+}
+
+func TestShiftTimeMultipleFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	require.NoError(t, c.ShiftTime(2*time.Second))
+	require.Equal(t, []IndexPoint{{Timestamp: 2 * time.Second}, {Timestamp: 3*time.Minute + 2*time.Second}, {Timestamp: 2 * time.Second}},
+		[]IndexPoint{c.Tracks[0].Index01, c.Tracks[1].Index01, c.Tracks[2].Index01})
+	require.NoError(t, c.validate())
+
+	err := c.ShiftTime(-3 * time.Second)
+	require.ErrorContains(t, err, "track 1: shifting 00:02:00 by -3s results in a negative index")
+}
+
+func TestApplyAndSubtractOffset(t *testing.T) {
+	newCueSheet := func() *CueSheet {
+		return &CueSheet{
+			Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+			Tracks: []Track{
+				{Number: 1, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Second}},
+				{
+					Number:  2,
+					Type:    TrackTypeAudio,
+					Index00: &IndexPoint{Timestamp: 58 * time.Second, Frame: 70},
+					Index01: IndexPoint{Timestamp: time.Minute},
+				},
+				{Number: 3, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			},
+			TotalLength: &IndexPoint{Timestamp: 3 * time.Minute},
+		}
+	}
+
+	c := newCueSheet()
+	c.ApplyOffset(IndexPoint{Timestamp: time.Second, Frame: 10})
+	require.Equal(t, IndexPoint{Timestamp: 3 * time.Second, Frame: 10}, c.Tracks[0].Index01)
+	require.Equal(t, &IndexPoint{Timestamp: time.Minute, Frame: 5}, c.Tracks[1].Index00)
+	require.Equal(t, IndexPoint{Timestamp: time.Minute + time.Second, Frame: 10}, c.Tracks[1].Index01)
+	require.Equal(t, IndexPoint{Timestamp: 2*time.Minute + time.Second, Frame: 10}, c.Tracks[2].Index01)
+	require.Equal(t, &IndexPoint{Timestamp: 3*time.Minute + time.Second, Frame: 10}, c.TotalLength)
+
+	require.NoError(t, c.SubtractOffset(IndexPoint{Timestamp: time.Second, Frame: 10}))
+	require.Equal(t, newCueSheet(), c)
+
+	require.NoError(t, c.SubtractOffset(IndexPoint{Timestamp: 2 * time.Second}))
+	require.Equal(t, IndexPoint{}, c.Tracks[0].Index01)
+	require.Equal(t, IndexPoint{Timestamp: 58 * time.Second}, c.Tracks[1].Index01)
+	require.NoError(t, c.validate())
+
+	err := c.SubtractOffset(IndexPoint{Frame: 1})
+	require.ErrorContains(t, err, "track 1: subtracting 00:00:01 from 00:00:00 results in a negative index")
+	require.Equal(t, IndexPoint{Timestamp: 58 * time.Second}, c.Tracks[1].Index01)
+}
+
+func TestSortTracks(t *testing.T) {
+	c := &CueSheet{
+		Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			{Number: 2, Type: TrackTypeAudio, Index01: IndexPoint{}},
+			{Number: 3, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute, Frame: 5}},
+		},
+	}
+	require.False(t, c.IsSorted())
+	require.Error(t, c.validate())
+
+	c.SortTracks()
+	require.True(t, c.IsSorted())
+	require.NoError(t, c.validate())
+	require.Equal(t, TrackList{
+		{Number: 1, Type: TrackTypeAudio, Index01: IndexPoint{}},
+		{Number: 2, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute, Frame: 5}},
+		{Number: 3, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+	}, c.Tracks)
+}
+
+func TestSortTracksMultipleFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	require.True(t, c.IsSorted())
+
+	c.Tracks[0], c.Tracks[2] = c.Tracks[2], c.Tracks[0]
+	require.False(t, c.IsSorted())
+	c.SortTracks()
+	require.Equal(t, multipleFilesCueSheet.Tracks, c.Tracks)
+	require.NoError(t, c.validate())
+}
+
+func TestAddTrack(t *testing.T) {
+	c := &CueSheet{Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}}}
+
+	require.NoError(t, c.AddTrack(&Track{Type: TrackTypeAudio}))
+	require.NoError(t, c.AddTrack(&Track{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}}))
+	require.Len(t, c.Tracks, 2)
+	require.Equal(t, 2, c.Tracks[1].Number)
+	require.NoError(t, c.validate())
+
+	err := c.AddTrack(&Track{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}})
+	require.ErrorContains(t, err, "overlapping indices in tracks 2 and 3")
+	require.Len(t, c.Tracks, 2)
+
+	err = c.AddTrack(&Track{Type: "VIDEO", Index01: IndexPoint{Timestamp: 2 * time.Minute}})
+	require.ErrorContains(t, err, "unsupported track type: VIDEO")
+	require.Len(t, c.Tracks, 2)
+}
+
+func TestAddTrackCopy(t *testing.T) {
+	c := &CueSheet{Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}}}
+	track := &Track{Type: TrackTypeAudio, Index00: &IndexPoint{Frame: 1}, Index01: IndexPoint{Frame: 2}, Remarks: []string{"LABEL Sample"}}
+	require.NoError(t, c.AddTrack(track))
+
+	track.Index00.Frame = 0
+	track.Remarks[0] = "LABEL Other"
+	require.Equal(t, &IndexPoint{Frame: 1}, c.Tracks[0].Index00)
+	require.Equal(t, []string{"LABEL Sample"}, c.Tracks[0].Remarks)
+}
+
+func TestAddTrackUnknownFile(t *testing.T) {
+	c := &CueSheet{Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}}}
+	err := c.AddTrack(&Track{Type: TrackTypeAudio, File: 5})
+	require.EqualError(t, err, "invalid track: track 1: unknown file 5")
+	require.Empty(t, c.Tracks)
+
+	err = c.InsertTrack(1, &Track{Type: TrackTypeAudio, File: -1})
+	require.EqualError(t, err, "invalid track: track 1: unknown file -1")
+	require.Empty(t, c.Tracks)
+}
+
+func TestAddTrackExceedsMaxTracks(t *testing.T) {
+	c := &CueSheet{}
+	for i := range maxTracks {
+		require.NoError(t, c.AddTrack(&Track{Type: TrackTypeAudio, Index01: IndexPointFromFrames(i)}))
+	}
+	err := c.AddTrack(&Track{Type: TrackTypeAudio, Index01: IndexPointFromFrames(maxTracks)})
+	require.ErrorContains(t, err, "cannot have more than 99 tracks")
+}
+
+func TestInsertTrack(t *testing.T) {
+	newCueSheet := func() *CueSheet {
+		return &CueSheet{
+			Tracks: []Track{
+				{Number: 1, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Second}},
+				{Number: 2, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}},
+				{Number: 3, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			},
+		}
+	}
+
+	t.Run("Middle", func(t *testing.T) {
+		c := newCueSheet()
+		track := Track{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 90 * time.Second}}
+		require.NoError(t, c.InsertTrack(3, &track))
+		require.Len(t, c.Tracks, 4)
+		track.Number = 3
+		require.Equal(t, track, c.Tracks[2])
+		require.Equal(t, 4, c.Tracks[3].Number)
+		require.True(t, c.IsSorted())
+	})
+
+	t.Run("First", func(t *testing.T) {
+		c := newCueSheet()
+		track := Track{Type: TrackTypeAudio}
+		require.NoError(t, c.InsertTrack(1, &track))
+		require.Len(t, c.Tracks, 4)
+		track.Number = 1
+		require.Equal(t, track, c.Tracks[0])
+		require.Equal(t, 2, c.Tracks[1].Number)
+	})
+
+	t.Run("AfterLast", func(t *testing.T) {
+		c := newCueSheet()
+		track := Track{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 3 * time.Minute}}
+		require.NoError(t, c.InsertTrack(4, &track))
+		require.Len(t, c.Tracks, 4)
+		track.Number = 4
+		require.Equal(t, track, c.Tracks[3])
+	})
+
+	t.Run("BeyondLast", func(t *testing.T) {
+		c := newCueSheet()
+		err := c.InsertTrack(5, &Track{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 3 * time.Minute}})
+		require.ErrorContains(t, err, "track position 5 out of range [1, 4]")
+		require.Equal(t, newCueSheet(), c)
+	})
+
+	t.Run("Overlapping", func(t *testing.T) {
+		c := newCueSheet()
+		err := c.InsertTrack(2, &Track{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 3 * time.Minute}})
+		require.ErrorContains(t, err, "overlapping indices in tracks 2 and 3")
+		require.Equal(t, newCueSheet(), c)
+	})
+}
+
+func TestRemoveTrack(t *testing.T) {
+	newCueSheet := func() *CueSheet {
+		return &CueSheet{
+			Tracks: []Track{
+				{Number: 1, Type: TrackTypeAudio, Title: "First"},
+				{Number: 2, Type: TrackTypeAudio, Title: "Second", Index01: IndexPoint{Timestamp: time.Minute}},
+				{Number: 3, Type: TrackTypeAudio, Title: "Third", Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			},
+		}
+	}
+
+	tcs := []struct {
+		name     string
+		n        int
+		removed  *Track
+		expected TrackList
+	}{
+		{
+			name:    "First",
+			n:       1,
+			removed: &Track{Number: 1, Type: TrackTypeAudio, Title: "First"},
+			expected: TrackList{
+				{Number: 1, Type: TrackTypeAudio, Title: "Second", Index01: IndexPoint{Timestamp: time.Minute}},
+				{Number: 2, Type: TrackTypeAudio, Title: "Third", Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			},
+		},
+		{
+			name:    "Middle",
+			n:       2,
+			removed: &Track{Number: 2, Type: TrackTypeAudio, Title: "Second", Index01: IndexPoint{Timestamp: time.Minute}},
+			expected: TrackList{
+				{Number: 1, Type: TrackTypeAudio, Title: "First"},
+				{Number: 2, Type: TrackTypeAudio, Title: "Third", Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			},
+		},
+		{
+			name:    "Last",
+			n:       3,
+			removed: &Track{Number: 3, Type: TrackTypeAudio, Title: "Third", Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			expected: TrackList{
+				{Number: 1, Type: TrackTypeAudio, Title: "First"},
+				{Number: 2, Type: TrackTypeAudio, Title: "Second", Index01: IndexPoint{Timestamp: time.Minute}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newCueSheet()
+			removed, err := c.RemoveTrack(tc.n)
+			require.NoError(t, err)
+			require.Equal(t, tc.removed, removed)
+			require.Equal(t, tc.expected, c.Tracks)
+		})
+	}
+
+	t.Run("NotFound", func(t *testing.T) {
+		c := newCueSheet()
+		for _, n := range []int{0, 4} {
+			removed, err := c.RemoveTrack(n)
+			require.ErrorIs(t, err, ErrTrackNotFound)
+			require.Nil(t, removed)
+		}
+		require.Equal(t, newCueSheet(), c)
+	})
+
+	t.Run("Overlapping", func(t *testing.T) {
+		c := newCueSheet()
+		c.Tracks[2].Index01 = IndexPoint{Timestamp: 30 * time.Second}
+		before := c.Clone()
+		removed, err := c.RemoveTrack(1)
+		require.EqualError(t, err, "invalid tracks after removal: overlapping indices in tracks 1 and 2")
+		require.Nil(t, removed)
+		require.Equal(t, before, c)
+	})
+}
+
+func TestSwapTracks(t *testing.T) {
+	// The second and third tracks were ripped in the wrong order.
+	c := &CueSheet{
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio, Title: "First"},
+			{Number: 2, Type: TrackTypeAudio, Title: "Third", Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			{Number: 3, Type: TrackTypeAudio, Title: "Second", Index01: IndexPoint{Timestamp: time.Minute}},
+		},
+	}
+	require.NoError(t, c.SwapTracks(3, 2))
+	require.Equal(t, TrackList{
+		{Number: 1, Type: TrackTypeAudio, Title: "First"},
+		{Number: 2, Type: TrackTypeAudio, Title: "Second", Index01: IndexPoint{Timestamp: time.Minute}},
+		{Number: 3, Type: TrackTypeAudio, Title: "Third", Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+	}, c.Tracks)
+
+	before := c.Clone()
+	err := c.SwapTracks(1, 3)
+	require.ErrorIs(t, err, ErrValidationFailed)
+	require.EqualError(t, err, "validation failed: overlapping indices in tracks 1 and 2")
+	require.Equal(t, before, c)
+
+	for _, n := range []int{0, 4} {
+		require.ErrorIs(t, c.SwapTracks(1, n), ErrTrackNotFound)
+		require.ErrorIs(t, c.SwapTracks(n, 1), ErrTrackNotFound)
+	}
+	require.Equal(t, before, c)
+}
+
+func TestMerge(t *testing.T) {
+	disc1, err := Parse(open(t, path.Join("file", "disc1.cue")))
+	require.NoError(t, err)
+	disc2, err := Parse(open(t, path.Join("file", "disc2.cue")))
+	require.NoError(t, err)
+
+	t.Run("Valid", func(t *testing.T) {
+		merged, err := disc1.Merge(disc2)
+		require.NoError(t, err)
+		require.Equal(t, &CueSheet{
+			AlbumPerformer: "Sample Album Artist",
+			AlbumTitle:     "Sample Album",
+			Files: []FileEntry{
+				{FileName: "disc1.wav", Format: AudioFormatWave},
+				{FileName: "disc2.wav", Format: AudioFormatWave},
+			},
+			Tracks: []Track{
+				disc1.Tracks[0],
+				disc1.Tracks[1],
+				{Number: 3, Type: TrackTypeAudio, File: 1},
+				{Number: 4, Type: TrackTypeAudio, File: 1, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			},
+		}, merged)
+		require.NotSame(t, disc1.Tracks[1].Index00, merged.Tracks[1].Index00)
+		require.Equal(t, 1, disc2.Tracks[0].Number)
+		require.Equal(t, 0, disc2.Tracks[0].File)
+
+		parsed, err := Parse(strings.NewReader(merged.String()))
+		require.NoError(t, err)
+		require.Equal(t, merged, parsed)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		other := disc2.Clone()
+		other.Files[0].FileName = ""
+		_, err := disc1.Merge(other)
+		require.EqualError(t, err, "invalid merged cue sheet: file 2: missing file name")
+	})
+
+	t.Run("FormatMismatch", func(t *testing.T) {
+		_, err := disc1.Merge(&CueSheet{Files: []FileEntry{{Format: AudioFormatMP3}}})
+		require.ErrorContains(t, err, "incompatible formats: WAVE and MP3")
+	})
+
+	t.Run("TrackCountOverflow", func(t *testing.T) {
+		other := &CueSheet{Files: []FileEntry{{Format: AudioFormatWave}}, Tracks: make([]Track, maxTracks-1)}
+		_, err := disc1.Merge(other)
+		require.ErrorContains(t, err, "merged cue sheet has 100 tracks, cannot have more than 99")
+	})
+}
+
+func TestSplit(t *testing.T) {
+	c := &CueSheet{
+		AlbumPerformer: "Sample Album Artist",
+		AlbumTitle:     "Sample Album",
+		Files:          []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio},
+			{Number: 2, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}},
+			{Number: 3, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+			{Number: 4, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 3 * time.Minute}},
+		},
+	}
+	withTracks := func(tracks []Track) *CueSheet {
+		return &CueSheet{
+			AlbumPerformer: c.AlbumPerformer,
+			AlbumTitle:     c.AlbumTitle,
+			Files:          c.Files,
+			Tracks:         tracks,
+		}
+	}
+
+	tcs := []struct {
+		name string
+		n    int
+	}{
+		{name: "Middle", n: 2},
+		{name: "First", n: 1},
+		{name: "Last", n: 4},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			first, second, err := c.Split(tc.n)
+			require.NoError(t, err)
+			require.Equal(t, withTracks(c.Tracks[:tc.n]), first)
+			rest := withTracks(slices.Clone(c.Tracks[tc.n:]))
+			rest.RenumberTracks()
+			require.Equal(t, rest, second)
+
+			merged, err := first.Merge(second)
+			require.NoError(t, err)
+			require.Equal(t, c, merged)
+		})
+	}
+
+	for _, n := range []int{0, 5} {
+		_, _, err := c.Split(n)
+		require.ErrorIs(t, err, ErrTrackNotFound)
+	}
+}
+
+func TestClone(t *testing.T) {
+	c, err := Parse(open(t, path.Join("index", "multiple.cue")))
+	require.NoError(t, err)
+	c.TotalLength = &IndexPoint{Timestamp: 3 * time.Minute}
+	c.Remarks = []string{"GENERATOR Some Ripper"}
+
+	clone := c.Clone()
+	require.Equal(t, c, clone)
+
+	clone.Tracks[1].Index00.Frame = 1
+	clone.Tracks[1].Indices[0].Frame = 1
+	clone.TotalLength.Frame = 1
+	clone.Remarks[0] = "changed"
+	require.Equal(t, 0, c.Tracks[1].Index00.Frame)
+	require.Equal(t, 0, c.Tracks[1].Indices[0].Frame)
+	require.Equal(t, 0, c.TotalLength.Frame)
+	require.Equal(t, "GENERATOR Some Ripper", c.Remarks[0])
+}
+
+func TestTrackClone(t *testing.T) {
+	track := multipleIndicesCueSheet.Tracks[1].clone()
+	track.Comments = []string{"Live"}
+	track.Remarks = []string{"LABEL Sample"}
+
+	clone := track.Clone()
+	require.Equal(t, &track, clone)
+
+	clone.Index00.Frame = 1
+	clone.Indices[0].Frame = 1
+	clone.Comments[0] = "changed"
+	clone.Remarks[0] = "changed"
+	require.Equal(t, 0, track.Index00.Frame)
+	require.Equal(t, 0, track.Indices[0].Frame)
+	require.Equal(t, "Live", track.Comments[0])
+	require.Equal(t, "LABEL Sample", track.Remarks[0])
+	require.Equal(t, multipleIndicesCueSheet.Tracks[1].Index00, track.Index00)
+}
+
+func TestWithoutRemarks(t *testing.T) {
+	c, err := Parse(open(t, path.Join("rem", "comments.cue")))
+	require.NoError(t, err)
+	require.NotEmpty(t, c.Remarks)
+
+	clone := c.WithoutRemarks()
+	require.Nil(t, clone.Remarks)
+	require.Nil(t, clone.Comments)
+	require.Nil(t, clone.Tracks[0].Comments)
+
+	require.Equal(t, commentsCueSheet, *c)
+
+	require.Nil(t, trackRemarksCueSheet.WithoutRemarks().Tracks[0].Remarks)
+	require.NotEmpty(t, trackRemarksCueSheet.Tracks[0].Remarks)
+}
+
+func TestAnonymize(t *testing.T) {
+	c, err := Parse(open(t, path.Join("track", "performer.cue")))
+	require.NoError(t, err)
+	c.AlbumTitle = "Sample Album"
+	c.Tracks[1].Title = "Second Track"
+	c.Date = "1989"
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCueSheet(c.Anonymize(), &buf))
+	anonymized, err := Parse(&buf)
+	require.NoError(t, err)
+	require.Empty(t, anonymized.AllPerformers())
+	require.Empty(t, anonymized.AlbumTitle)
+	require.Equal(t, []string{"", ""}, anonymized.TrackTitles())
+	require.Equal(t, c.Files, anonymized.Files)
+	require.Equal(t, c.Date, anonymized.Date)
+	for i, track := range anonymized.Tracks {
+		require.Equal(t, c.Tracks[i].IndexPoints(), track.IndexPoints())
+	}
+
+	require.Equal(t, "Various Artists", c.AlbumPerformer)
+	require.Equal(t, "First Artist", c.Tracks[0].Performer)
+}
+
+func TestFlatten(t *testing.T) {
+	c := &CueSheet{
+		Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio},
+			{Number: 2, Type: TrackTypeAudio, Index00: &IndexPoint{}, Index01: IndexPoint{Timestamp: time.Minute}},
+			{Number: 3, Type: TrackTypeAudio, Index00: &IndexPoint{Timestamp: 110 * time.Second}, Index01: IndexPoint{Timestamp: 2 * time.Minute}},
+		},
+	}
+	require.ErrorContains(t, c.validate(), "overlapping indices in tracks 1 and 2")
+
+	c.Flatten()
+	require.Nil(t, c.Tracks[1].Index00)
+	require.Equal(t, &IndexPoint{Timestamp: 110 * time.Second}, c.Tracks[2].Index00)
+	require.NoError(t, c.validate())
+}
+
+func TestFlattenMultipleFiles(t *testing.T) {
+	c := &CueSheet{
+		Files: []FileEntry{{FileName: "first.wav", Format: AudioFormatWave}, {FileName: "second.wav", Format: AudioFormatWave}},
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio},
+			{Number: 2, Type: TrackTypeAudio, File: 1, Index00: &IndexPoint{}, Index01: IndexPoint{Timestamp: 2 * time.Second}},
+		},
+	}
+	require.NoError(t, c.validate())
+
+	// The pregap starts the second file, it is not at the position of the previous track.
+	c.Flatten()
+	require.Equal(t, &IndexPoint{}, c.Tracks[1].Index00)
+}
+
+func TestRenumberTracks(t *testing.T) {
+	c, err := Parse(open(t, path.Join("index", "multiple.cue")))
+	require.NoError(t, err)
+	require.True(t, c.NumbersAreSequential())
+
+	c.Tracks = slices.Insert(c.Tracks, 1, Track{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 40 * time.Second}})
+	require.False(t, c.NumbersAreSequential())
+	c.RenumberTracks()
+	require.True(t, c.NumbersAreSequential())
+	require.Equal(t, []int{1, 2, 3}, []int{c.Tracks[0].Number, c.Tracks[1].Number, c.Tracks[2].Number})
+	require.NoError(t, c.validate())
+
+	c.Tracks = slices.Delete(c.Tracks, 1, 2)
+	require.False(t, c.NumbersAreSequential())
+	c.RenumberTracks()
+	require.True(t, c.NumbersAreSequential())
+	require.Equal(t, multipleIndicesCueSheet, *c)
+}
+
+func TestSetTitles(t *testing.T) {
+	c := allCueSheet.Clone()
+
+	require.NoError(t, c.SetTrackTitle(2, "Corrected Title"))
+	require.Equal(t, "Corrected Title", c.Tracks[1].Title)
+	for _, n := range []int{0, 3} {
+		require.ErrorIs(t, c.SetTrackTitle(n, "Title"), ErrTrackNotFound)
+	}
+	require.EqualError(t, c.SetTrackTitle(1, ""), "empty title")
+	require.Equal(t, "First Track", c.Tracks[0].Title)
+
+	require.NoError(t, c.SetAlbumTitle("New Album"))
+	require.Equal(t, "New Album", c.AlbumTitle)
+	require.EqualError(t, c.SetAlbumTitle(""), "empty title")
+
+	require.NoError(t, c.SetAlbumPerformer("New Artist"))
+	require.Equal(t, "New Artist", c.AlbumPerformer)
+	require.EqualError(t, c.SetAlbumPerformer(""), "empty performer")
+	require.Equal(t, "New Artist", c.AlbumPerformer)
+}
+
+func TestRemarks(t *testing.T) {
+	c := minimalCueSheet.Clone()
+	require.Nil(t, c.Remarks)
+	require.Zero(t, c.RemarkCount())
+
+	require.NoError(t, c.AddRemark("GENERATOR Some Ripper"))
+	require.NoError(t, c.AddRemark("LABEL Sample"))
+	require.Equal(t, []string{"GENERATOR Some Ripper", "LABEL Sample"}, c.Remarks)
+	require.Equal(t, 2, c.RemarkCount())
+
+	require.EqualError(t, c.AddRemark(""), "empty remark")
+	require.EqualError(t, c.AddRemark(" "), "empty remark")
+	require.EqualError(t, c.AddRemark("LABEL\nSample"), `remark spans several lines: "LABEL\nSample"`)
+	require.EqualError(t, c.AddRemark("DATE 2001"), `remark starts with the REM key DATE: "DATE 2001"`)
+	require.EqualError(t, c.AddRemark(" DISCID 860B640B"), `remark starts with the REM key DISCID: " DISCID 860B640B"`)
+	require.EqualError(t, c.AddRemark("COMMENT Sample"), `remark starts with the REM key COMMENT: "COMMENT Sample"`)
+	require.Equal(t, 2, c.RemarkCount())
+
+	// The remarks are read back as remarks.
+	parsed, err := Parse(strings.NewReader(c.String()))
+	require.NoError(t, err)
+	require.Equal(t, c.Remarks, parsed.Remarks)
+
+	c.ClearRemarks()
+	require.Nil(t, c.Remarks)
+	require.Zero(t, c.RemarkCount())
+}
+
+func TestNormalize(t *testing.T) {
+	c := &CueSheet{
+		AlbumPerformer: "  Sample Album Artist\t",
+		AlbumTitle:     "Sample Album ",
+		Date:           " 1999",
+		Remarks:        []string{"GENERATOR Some Ripper", "", "  "},
+		Files:          []FileEntry{{FileName: "sample.flac", Format: "wave"}},
+		Tracks: []Track{
+			{Number: 1, Type: "audio", Title: " First Track  ", Remarks: []string{" "}},
+			{Number: 2, Type: "Mode1/2352", Title: "Second Track", Index01: IndexPoint{Timestamp: time.Minute}, Remarks: []string{"LABEL Sample", ""}},
+		},
+	}
+	require.NoError(t, c.Normalize())
+	require.Equal(t, &CueSheet{
+		AlbumPerformer: "Sample Album Artist",
+		AlbumTitle:     "Sample Album",
+		Date:           "1999",
+		Remarks:        []string{"GENERATOR Some Ripper"},
+		Files:          []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio, Title: "First Track"},
+			{Number: 2, Type: TrackTypeMode12352, Title: "Second Track", Index01: IndexPoint{Timestamp: time.Minute}, Remarks: []string{"LABEL Sample"}},
+		},
+	}, c)
+
+	normalized := c.Clone()
+	require.NoError(t, normalized.Normalize())
+	require.Equal(t, c, normalized)
+
+	// Formats outside of the specification are accepted, as by Parse.
+	flac := normalized.Clone()
+	flac.Files[0].Format = "flac"
+	require.NoError(t, flac.Normalize())
+	require.Equal(t, AudioFormat("FLAC"), flac.Files[0].Format)
+
+	t.Run("Invalid", func(t *testing.T) {
+		tcs := []struct {
+			name        string
+			modify      func(c *CueSheet)
+			expectedErr string
+		}{
+			{name: "TrackType", modify: func(c *CueSheet) { c.Tracks[1].Type = "video" }, expectedErr: "track 2: unsupported track type: VIDEO"},
+			{name: "Tracks", modify: func(c *CueSheet) { c.Tracks[1].Index01 = IndexPoint{} }, expectedErr: "invalid normalized cue sheet: invalid tracks: overlapping indices in tracks 1 and 2"},
+		}
+		for _, tc := range tcs {
+			t.Run(tc.name, func(t *testing.T) {
+				invalid := normalized.Clone()
+				invalid.AlbumTitle = " Sample Album"
+				tc.modify(invalid)
+				before := invalid.Clone()
+				require.EqualError(t, invalid.Normalize(), tc.expectedErr)
+				require.Equal(t, before, invalid)
+			})
+		}
+	})
+}
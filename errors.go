@@ -0,0 +1,45 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"strings"
+)
+
+// MultiError holds several errors, such as every rule broken by an invalid cue sheet
+// when parsing with ParseOptions.CollectAllErrors.
+type MultiError struct {
+	errs []error
+}
+
+// NewMultiError returns a MultiError holding errs.
+func NewMultiError(errs ...error) *MultiError {
+	return &MultiError{errs: errs}
+}
+
+// Error implements the error interface, with the message of every error on its own line.
+func (e *MultiError) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Errors returns the errors held by e.
+func (e *MultiError) Errors() []error {
+	return e.errs
+}
+
+// Unwrap returns the errors held by e, so that errors.Is and errors.As look into all of them.
+func (e *MultiError) Unwrap() []error {
+	return e.errs
+}
+
+// AsMultiError returns the first MultiError in the chain of err.
+func AsMultiError(err error) (*MultiError, bool) {
+	var multiErr *MultiError
+	if errors.As(err, &multiErr) {
+		return multiErr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,69 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiError(t *testing.T) {
+	errs := []error{errors.New("first"), fmt.Errorf("second: %w", fs.ErrNotExist)}
+	multiErr := NewMultiError(errs...)
+	require.Equal(t, "first\nsecond: file does not exist", multiErr.Error())
+	require.Equal(t, errs, multiErr.Errors())
+	require.ErrorIs(t, multiErr, fs.ErrNotExist)
+
+	found, ok := AsMultiError(fmt.Errorf("wrapped: %w", multiErr))
+	require.True(t, ok)
+	require.Same(t, multiErr, found)
+
+	found, ok = AsMultiError(errs[0])
+	require.False(t, ok)
+	require.Nil(t, found)
+}
+
+func TestParseCollectAllErrors(t *testing.T) {
+	const input = `REM DISCNUMBER 3
+REM TOTALDISCS 2
+FILE sample.flac WAVE
+TRACK 01 AUDIO
+  INDEX 01 01:00:00
+TRACK 02 AUDIO
+  INDEX 00 00:40:00
+  INDEX 01 00:30:00
+`
+	_, err := Parse(strings.NewReader(input))
+	require.EqualError(t, err, "invalid cue sheet: invalid tracks: overlapping indices in tracks 1 and 2")
+	_, ok := AsMultiError(err)
+	require.False(t, ok)
+
+	_, err = ParseWithOptions(strings.NewReader(input), ParseOptions{CollectAllErrors: true})
+	multiErr, ok := AsMultiError(err)
+	require.True(t, ok)
+	require.Len(t, multiErr.Errors(), 3)
+	require.EqualError(t, err, `invalid cue sheet: invalid tracks: overlapping indices in tracks 1 and 2
+invalid tracks: track 2: INDEX 00 must be before INDEX 01
+disc number 3 exceeds total discs 2`)
+
+	_, err = ParseWithOptions(strings.NewReader(input), ParseOptions{CollectAllErrors: true, SkipValidation: true})
+	require.NoError(t, err)
+
+	// A missing INDEX 01 is reported once, without the ordering and overlap errors it implies.
+	const missingIndex01 = `FILE sample.flac WAVE
+TRACK 01 AUDIO
+  INDEX 01 00:00:00
+TRACK 02 AUDIO
+  INDEX 00 01:00:00
+TRACK 03 AUDIO
+  INDEX 01 01:30:00
+`
+	_, err = ParseWithOptions(strings.NewReader(missingIndex01), ParseOptions{CollectAllErrors: true})
+	multiErr, ok = AsMultiError(err)
+	require.True(t, ok)
+	require.Len(t, multiErr.Errors(), 1)
+	require.EqualError(t, err, "invalid cue sheet: invalid tracks: track 2: missing INDEX 01")
+}
@@ -0,0 +1,243 @@
+package cuesheetgo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Chapter is the format-neutral view of a track for chapter-aware players and exporters.
+type Chapter struct {
+	Number    int
+	Title     string
+	Performer string
+	// Start is the INDEX 01 of the track.
+	Start IndexPoint
+	// End is the INDEX 01 of the next track in the same file, nil when unknown.
+	End  *IndexPoint
+	ISRC string
+}
+
+// Chapters returns one chapter per track of the cue sheet, in order.
+func (c *CueSheet) Chapters() []Chapter {
+	chapters := make([]Chapter, len(c.Tracks))
+	for i, track := range c.Tracks {
+		chapters[i] = Chapter{
+			Number:    track.Number,
+			Title:     track.Title,
+			Performer: track.Performer,
+			Start:     track.Index01,
+			ISRC:      track.ISRC,
+		}
+		// Index points restart from zero in every file.
+		if i < len(c.Tracks)-1 && c.Tracks[i+1].File == track.File {
+			end := c.Tracks[i+1].Index01
+			chapters[i].End = &end
+		}
+	}
+	return chapters
+}
+
+// exportChapters returns the chapters of the cue sheet, the last one ending at the
+// total length when known.
+func (c *CueSheet) exportChapters() []Chapter {
+	chapters := c.Chapters()
+	if len(chapters) > 0 && c.TotalLength != nil {
+		end := *c.TotalLength
+		chapters[len(chapters)-1].End = &end
+	}
+	return chapters
+}
+
+// ExportOGGChapters returns the Vorbis comment chapter lines for the tracks of the cue sheet,
+// in the CHAPTERnnn=HH:MM:SS.mmm and CHAPTERnnnNAME=title format.
+// Every track but the first of each file must have a non-zero INDEX 01.
+func ExportOGGChapters(c *CueSheet) ([]string, error) {
+	lines := make([]string, 0, 2*len(c.Tracks))
+	for i, track := range c.Tracks {
+		if i > 0 && track.File == c.Tracks[i-1].File && track.Index01.IsZero() {
+			return nil, fmt.Errorf("track %d: missing INDEX 01", i+1)
+		}
+		lines = append(lines, fmt.Sprintf("CHAPTER%03d=%s", i+1, formatClock(track.Index01, time.Millisecond)))
+		if track.Title != "" {
+			lines = append(lines, fmt.Sprintf("CHAPTER%03dNAME=%s", i+1, track.Title))
+		}
+	}
+	return lines, nil
+}
+
+// matroskaChapters is the root of a Matroska XML chapters document.
+type matroskaChapters struct {
+	XMLName      xml.Name `xml:"Chapters"`
+	EditionEntry struct {
+		ChapterAtoms []matroskaChapterAtom `xml:"ChapterAtom"`
+	}
+}
+
+type matroskaChapterAtom struct {
+	ChapterTimeStart string
+	ChapterDisplay   *matroskaChapterDisplay `xml:",omitempty"`
+}
+
+type matroskaChapterDisplay struct {
+	ChapterString string
+}
+
+// ExportMatroskaChapters writes the tracks of the cue sheet to w as a Matroska XML chapters document,
+// with one chapter per track starting at its INDEX 01.
+func ExportMatroskaChapters(c *CueSheet, w io.Writer) error {
+	var chapters matroskaChapters
+	for _, chapter := range c.Chapters() {
+		atom := matroskaChapterAtom{ChapterTimeStart: formatClock(chapter.Start, time.Nanosecond)}
+		if chapter.Title != "" {
+			atom.ChapterDisplay = &matroskaChapterDisplay{ChapterString: chapter.Title}
+		}
+		chapters.EditionEntry.ChapterAtoms = append(chapters.EditionEntry.ChapterAtoms, atom)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(chapters); err != nil {
+		return fmt.Errorf("error encoding Matroska chapters: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// ffmpegEscaper escapes the characters with a special meaning in FFmpeg metadata files.
+var ffmpegEscaper = strings.NewReplacer(`\`, `\\`, "=", `\=`, ";", `\;`, "#", `\#`, "\n", "\\\n")
+
+// ExportFFmpegChapters writes the tracks of the cue sheet to w as FFmpeg metadata chapters,
+// using the CD frame as time base. A chapter ends one frame before the next one starts in the
+// same file. The last chapter ends at the total length when known. Otherwise its END is 0.
+func ExportFFmpegChapters(c *CueSheet, w io.Writer) error {
+	cw := &cueWriter{w: w}
+	cw.line("", ";FFMETADATA1")
+	for _, chapter := range c.exportChapters() {
+		cw.line("", "[CHAPTER]")
+		cw.line("", "TIMEBASE=1/%d", framesPerSecond)
+		cw.line("", "START=%d", chapter.Start.AbsoluteFrames())
+		if chapter.End != nil {
+			cw.line("", "END=%d", chapter.End.AbsoluteFrames()-1)
+		} else {
+			cw.line("", "; the end of the track is unknown")
+			cw.line("", "END=0")
+		}
+		if chapter.Title != "" {
+			cw.line("", "title=%s", ffmpegEscaper.Replace(chapter.Title))
+		}
+	}
+	return cw.err
+}
+
+// maxIndexPoint is the largest position that can be expressed in a cue sheet.
+var maxIndexPoint = IndexPoint{Timestamp: 99*time.Minute + 59*time.Second, Frame: framesPerSecond - 1}
+
+// ExportWebVTT writes the tracks of the cue sheet to w as WebVTT chapter cues.
+func ExportWebVTT(c *CueSheet, w io.Writer) error {
+	return ExportWebVTTWithOptions(c, w, WriteOptions{})
+}
+
+// ExportWebVTTWithOptions is like ExportWebVTT but allows configuring the export through opts.
+// Each cue ends where the next track starts in the same file. The last one ends at the total
+// length when known. Otherwise a cue ends at the largest position a cue sheet can express.
+// Tracks without a title are named after their number, unless opts.RequireTrackTitles is set.
+func ExportWebVTTWithOptions(c *CueSheet, w io.Writer, opts WriteOptions) error {
+	cw := &cueWriter{w: w}
+	cw.line("", "WEBVTT")
+	for i, chapter := range c.exportChapters() {
+		title := chapter.Title
+		if title == "" {
+			if opts.RequireTrackTitles {
+				return fmt.Errorf("track %d: missing title", i+1)
+			}
+			title = fmt.Sprintf("Track %02d", i+1)
+		}
+		end := maxIndexPoint
+		if chapter.End != nil {
+			end = *chapter.End
+		}
+		cw.line("", "")
+		cw.line("", "%s --> %s", formatClock(chapter.Start, time.Millisecond), formatClock(end, time.Millisecond))
+		cw.line("", "%s", title)
+	}
+	return cw.err
+}
+
+// M3UOptions configures ExportM3U.
+type M3UOptions struct {
+	// Extended writes the #EXTM3U header and an #EXTINF line for every track.
+	Extended bool
+	// AbsolutePaths resolves the file name against the current working directory.
+	AbsolutePaths bool
+}
+
+// ExportM3U writes the cue sheet to w as an M3U playlist.
+// In the extended format each track is an #EXTINF entry with its duration in seconds,
+// or -1 when unknown, and its performer and title, followed by the name of the audio file
+// it starts in. The album performer is used for the tracks without a performer.
+// Otherwise every file is listed once.
+func ExportM3U(c *CueSheet, w io.Writer, opts M3UOptions) error {
+	fileNames := make([]string, len(c.Files))
+	for i, file := range c.Files {
+		fileNames[i] = file.FileName
+		if opts.AbsolutePaths {
+			var err error
+			if fileNames[i], err = filepath.Abs(file.FileName); err != nil {
+				return fmt.Errorf("error resolving file name: %w", err)
+			}
+		}
+	}
+
+	cw := &cueWriter{w: w}
+	if !opts.Extended {
+		for _, fileName := range fileNames {
+			cw.line("", "%s", fileName)
+		}
+		return cw.err
+	}
+	cw.line("", "#EXTM3U")
+	for i, track := range c.Tracks {
+		duration := -1
+		switch {
+		case i < len(c.Tracks)-1 && c.Tracks[i+1].File == track.File:
+			duration = (c.Tracks[i+1].Index01.AbsoluteFrames() - track.Index01.AbsoluteFrames()) / framesPerSecond
+		case i == len(c.Tracks)-1 && c.TotalLength != nil:
+			duration = (c.TotalLength.AbsoluteFrames() - track.Index01.AbsoluteFrames()) / framesPerSecond
+		}
+		title := track.Title
+		if title == "" {
+			title = fmt.Sprintf("Track %02d", i+1)
+		}
+		performer := track.Performer
+		if performer == "" {
+			performer = c.AlbumPerformer
+		}
+		if performer != "" {
+			title = performer + " - " + title
+		}
+		cw.line("", "#EXTINF:%d,%s", duration, title)
+		cw.line("", "%s", fileNames[track.File])
+	}
+	return cw.err
+}
+
+// formatClock formats the index point as HH:MM:SS followed by the fraction of a second
+// in the given precision, e.g. HH:MM:SS.mmm for time.Millisecond.
+func formatClock(idx IndexPoint, precision time.Duration) string {
+	var (
+		hours   = int(idx.Timestamp / time.Hour)
+		minutes = int(idx.Timestamp % time.Hour / time.Minute)
+		seconds = int(idx.Timestamp % time.Minute / time.Second)
+
+		fraction = FrameToDuration(idx.Frame) / precision
+		digits   = len(fmt.Sprint(int(time.Second / precision)))
+	)
+	return fmt.Sprintf("%02d:%02d:%02d.%0*d", hours, minutes, seconds, digits-1, fraction)
+}
@@ -0,0 +1,246 @@
+package cuesheetgo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChapters(t *testing.T) {
+	c := allCueSheet.Clone()
+	c.Tracks[0].Performer = "First Artist"
+	c.Tracks[1].ISRC = "USRC17607839"
+	require.Equal(t, []Chapter{
+		{Number: 1, Title: "First Track", Performer: "First Artist", Start: IndexPoint{Timestamp: time.Second}, End: &IndexPoint{Timestamp: time.Minute}},
+		{Number: 2, Title: "Second Track", Start: IndexPoint{Timestamp: time.Minute}, ISRC: "USRC17607839"},
+	}, c.Chapters())
+
+	chapters := multipleFilesCueSheet.Chapters()
+	require.Len(t, chapters, 3)
+	require.Equal(t, &multipleFilesCueSheet.Tracks[1].Index01, chapters[0].End)
+	require.Nil(t, chapters[1].End)
+	require.Nil(t, chapters[2].End)
+
+	require.Empty(t, (&CueSheet{}).Chapters())
+}
+
+func TestExportOGGChapters(t *testing.T) {
+	lines, err := ExportOGGChapters(&allCueSheet)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"CHAPTER001=00:00:01.000",
+		"CHAPTER001NAME=First Track",
+		"CHAPTER002=00:01:00.000",
+		"CHAPTER002NAME=Second Track",
+	}, lines)
+}
+
+func TestExportOGGChaptersFrames(t *testing.T) {
+	c := &CueSheet{
+		Tracks: []Track{
+			{Type: TrackTypeAudio},
+			{Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Hour + 23*time.Second, Frame: 34}},
+		},
+	}
+	lines, err := ExportOGGChapters(c)
+	require.NoError(t, err)
+	require.Equal(t, []string{"CHAPTER001=00:00:00.000", "CHAPTER002=01:00:23.453"}, lines)
+}
+
+func TestExportOGGChaptersMissingIndex(t *testing.T) {
+	c := &CueSheet{Tracks: []Track{{Type: TrackTypeAudio}, {Type: TrackTypeAudio}}}
+	_, err := ExportOGGChapters(c)
+	require.ErrorContains(t, err, "track 2: missing INDEX 01")
+}
+
+func TestExportOGGChaptersMultipleFiles(t *testing.T) {
+	lines, err := ExportOGGChapters(&multipleFilesCueSheet)
+	require.NoError(t, err)
+	require.Equal(t, []string{"CHAPTER001=00:00:00.000", "CHAPTER002=00:03:00.000", "CHAPTER003=00:00:00.000"}, lines)
+}
+
+func TestExportMatroskaChapters(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportMatroskaChapters(&allCueSheet, &buf))
+	require.Contains(t, buf.String(), xml.Header)
+
+	var chapters matroskaChapters
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &chapters))
+	atoms := chapters.EditionEntry.ChapterAtoms
+	require.Len(t, atoms, len(allCueSheet.Tracks))
+	require.Equal(t, "00:00:01.000000000", atoms[0].ChapterTimeStart)
+	require.Equal(t, "First Track", atoms[0].ChapterDisplay.ChapterString)
+	require.Equal(t, "00:01:00.000000000", atoms[1].ChapterTimeStart)
+}
+
+func TestExportFFmpegChapters(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportFFmpegChapters(&allCueSheet, &buf))
+	require.True(t, strings.HasPrefix(buf.String(), ";FFMETADATA1\n"))
+
+	var starts, ends []int
+	var titles []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || strings.HasPrefix(line, ";") {
+			continue
+		}
+		switch key {
+		case "TIMEBASE":
+			require.Equal(t, "1/75", value)
+		case "START", "END":
+			n, err := strconv.Atoi(value)
+			require.NoError(t, err)
+			if key == "START" {
+				starts = append(starts, n)
+			} else {
+				ends = append(ends, n)
+			}
+		case "title":
+			titles = append(titles, value)
+		}
+	}
+	require.Equal(t, []int{
+		allCueSheet.Tracks[0].Index01.AbsoluteFrames(),
+		allCueSheet.Tracks[1].Index01.AbsoluteFrames(),
+	}, starts)
+	require.Equal(t, []int{75, 4500}, starts)
+	require.Equal(t, []int{4499, 0}, ends)
+	require.Equal(t, []string{"First Track", "Second Track"}, titles)
+	require.Contains(t, buf.String(), "; the end of the track is unknown\nEND=0\n")
+}
+
+func TestExportFFmpegChaptersTotalLength(t *testing.T) {
+	c := &CueSheet{
+		Tracks:      []Track{{Type: TrackTypeAudio, Title: "A=B; #1"}},
+		TotalLength: &IndexPoint{Timestamp: time.Second},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, ExportFFmpegChapters(c, &buf))
+	require.Equal(t, ";FFMETADATA1\n[CHAPTER]\nTIMEBASE=1/75\nSTART=0\nEND=74\ntitle=A\\=B\\; \\#1\n", buf.String())
+}
+
+func TestExportFFmpegChaptersMultipleFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	c.TotalLength = &IndexPoint{Timestamp: time.Minute}
+	var buf bytes.Buffer
+	require.NoError(t, ExportFFmpegChapters(c, &buf))
+	require.Equal(t, `;FFMETADATA1
+[CHAPTER]
+TIMEBASE=1/75
+START=0
+END=13499
+[CHAPTER]
+TIMEBASE=1/75
+START=13500
+; the end of the track is unknown
+END=0
+[CHAPTER]
+TIMEBASE=1/75
+START=0
+END=4499
+`, buf.String())
+}
+
+func TestExportWebVTT(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportWebVTT(&allCueSheet, &buf))
+	lines := strings.Split(buf.String(), "\n")
+	require.Equal(t, "WEBVTT", lines[0])
+	require.Equal(t, "00:00:01.000 --> 00:01:00.000", lines[2])
+	require.Equal(t, "First Track", lines[3])
+	require.Equal(t, "00:01:00.000 --> 01:39:59.986", lines[5])
+}
+
+func TestExportWebVTTMultipleFiles(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportWebVTT(&multipleFilesCueSheet, &buf))
+	require.Equal(t, `WEBVTT
+
+00:00:00.000 --> 00:03:00.000
+Track 01
+
+00:03:00.000 --> 01:39:59.986
+Track 02
+
+00:00:00.000 --> 01:39:59.986
+Track 03
+`, buf.String())
+}
+
+func TestExportWebVTTMissingTitle(t *testing.T) {
+	c := &CueSheet{
+		Tracks:      []Track{{Type: TrackTypeAudio, Index01: IndexPoint{Frame: 1}}},
+		TotalLength: &IndexPoint{Timestamp: time.Second},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportWebVTT(c, &buf))
+	require.Equal(t, "WEBVTT\n\n00:00:00.013 --> 00:00:01.000\nTrack 01\n", buf.String())
+
+	err := ExportWebVTTWithOptions(c, &buf, WriteOptions{RequireTrackTitles: true})
+	require.ErrorContains(t, err, "track 1: missing title")
+}
+
+func TestExportM3U(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportM3U(&allCueSheet, &buf, M3UOptions{Extended: true}))
+	require.Equal(t, []string{
+		"#EXTM3U",
+		"#EXTINF:59,Sample Album Artist - First Track",
+		"sample.flac",
+		"#EXTINF:-1,Sample Album Artist - Second Track",
+		"sample.flac",
+	}, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"))
+}
+
+func TestExportM3UTrackPerformer(t *testing.T) {
+	c := allCueSheet.Clone()
+	c.Tracks[1].Performer = "Guest Artist"
+	var buf bytes.Buffer
+	require.NoError(t, ExportM3U(c, &buf, M3UOptions{Extended: true}))
+	require.Equal(t, []string{
+		"#EXTM3U",
+		"#EXTINF:59,Sample Album Artist - First Track",
+		"sample.flac",
+		"#EXTINF:-1,Guest Artist - Second Track",
+		"sample.flac",
+	}, strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"))
+}
+
+func TestExportM3UOptions(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportM3U(&allCueSheet, &buf, M3UOptions{}))
+	require.Equal(t, "sample.flac\n", buf.String())
+
+	abs, err := filepath.Abs("sample.flac")
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, ExportM3U(&allCueSheet, &buf, M3UOptions{AbsolutePaths: true}))
+	require.Equal(t, abs+"\n", buf.String())
+}
+
+func TestExportM3UTotalLength(t *testing.T) {
+	c := &CueSheet{
+		Files:       []FileEntry{{FileName: "sample.flac"}},
+		Tracks:      []Track{{Type: TrackTypeAudio}},
+		TotalLength: &IndexPoint{Timestamp: 3 * time.Minute, Frame: 74},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, ExportM3U(c, &buf, M3UOptions{Extended: true}))
+	require.Equal(t, "#EXTM3U\n#EXTINF:180,Track 01\nsample.flac\n", buf.String())
+}
+
+func TestExportM3UMultipleFilesTotalLength(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	c.TotalLength = &IndexPoint{Timestamp: time.Minute}
+	var buf bytes.Buffer
+	require.NoError(t, ExportM3U(c, &buf, M3UOptions{Extended: true}))
+	require.Equal(t, "#EXTM3U\n#EXTINF:180,Track 01\nfirst.wav\n#EXTINF:-1,Track 02\nfirst.wav\n#EXTINF:60,Track 03\nsecond.wav\n", buf.String())
+}
@@ -0,0 +1,44 @@
+package cuesheetgo
+
+// FileName returns the name of the first audio file of the cue sheet,
+// or an empty string when it has none.
+func (c *CueSheet) FileName() string {
+	if len(c.Files) == 0 {
+		return ""
+	}
+	return c.Files[0].FileName
+}
+
+// Format returns the format of the first audio file of the cue sheet,
+// or an empty string when it has none.
+func (c *CueSheet) Format() AudioFormat {
+	if len(c.Files) == 0 {
+		return ""
+	}
+	return c.Files[0].Format
+}
+
+// FileTracks returns pointers to the tracks starting in the file at index i of Files.
+func (c *CueSheet) FileTracks(i int) []*Track {
+	return c.filterTracks(func(t *Track) bool { return t.File == i })
+}
+
+// FileCount returns the number of audio files of the cue sheet.
+func (c *CueSheet) FileCount() int {
+	return len(c.Files)
+}
+
+// IsMultiFile reports whether the cue sheet refers to more than one audio file.
+func (c *CueSheet) IsMultiFile() bool {
+	return len(c.Files) > 1
+}
+
+// FilesForTrack returns the file in which the track with the 1-based number n starts.
+// It returns nil if n is out of range or the track refers to an unknown file.
+func (c *CueSheet) FilesForTrack(n int) *FileEntry {
+	track, ok := c.Track(n)
+	if !ok || track.File < 0 || track.File >= len(c.Files) {
+		return nil
+	}
+	return &c.Files[track.File]
+}
@@ -0,0 +1,55 @@
+package cuesheetgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	require.Equal(t, "first.wav", c.FileName())
+	require.Equal(t, AudioFormatWave, c.Format())
+	require.Equal(t, []*Track{&c.Tracks[0], &c.Tracks[1]}, c.FileTracks(0))
+	require.Equal(t, []*Track{&c.Tracks[2]}, c.FileTracks(1))
+	require.Empty(t, c.FileTracks(2))
+
+	require.Empty(t, (&CueSheet{}).FileName())
+	require.Empty(t, (&CueSheet{}).Format())
+}
+
+func TestFileCount(t *testing.T) {
+	require.Equal(t, 1, allCueSheet.FileCount())
+	require.False(t, allCueSheet.IsMultiFile())
+	require.Equal(t, 2, multipleFilesCueSheet.FileCount())
+	require.True(t, multipleFilesCueSheet.IsMultiFile())
+	require.Zero(t, (&CueSheet{}).FileCount())
+	require.False(t, (&CueSheet{}).IsMultiFile())
+}
+
+func TestFilesForTrack(t *testing.T) {
+	c := allCueSheet.Clone()
+	require.Same(t, &c.Files[0], c.FilesForTrack(1))
+	require.Same(t, &c.Files[0], c.FilesForTrack(2))
+
+	c = multipleFilesCueSheet.Clone()
+	require.Same(t, &c.Files[0], c.FilesForTrack(2))
+	require.Same(t, &c.Files[1], c.FilesForTrack(3))
+	for _, n := range []int{0, 4} {
+		require.Nil(t, c.FilesForTrack(n), "track %d", n)
+	}
+
+	c.Tracks[2].File = 2
+	require.Nil(t, c.FilesForTrack(3))
+}
+
+func TestValidateTrackFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	c.Tracks[2].File = 2
+	require.ErrorContains(t, c.validate(), "track 3: unknown file 2")
+
+	c = multipleFilesCueSheet.Clone()
+	c.Tracks[1].File = 1
+	c.Tracks[2].File = 0
+	require.ErrorContains(t, c.validate(), "track 3: file 0 comes before the file of the previous track")
+}
@@ -0,0 +1,116 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnknownFormat is returned when the audio format cannot be inferred from a file name.
+var ErrUnknownFormat = errors.New("unknown audio format")
+
+// AudioFormat is the type of the audio file referenced by the FILE command.
+type AudioFormat string
+
+const (
+	AudioFormatBinary   AudioFormat = "BINARY"
+	AudioFormatMotorola AudioFormat = "MOTOROLA"
+	AudioFormatAIFF     AudioFormat = "AIFF"
+	AudioFormatWave     AudioFormat = "WAVE"
+	AudioFormatMP3      AudioFormat = "MP3"
+)
+
+// String implements the fmt.Stringer interface.
+func (f AudioFormat) String() string {
+	return string(f)
+}
+
+// Valid reports whether f is one of the formats defined by the cue sheet specification.
+func (f AudioFormat) Valid() bool {
+	switch f {
+	case AudioFormatBinary, AudioFormatMotorola, AudioFormatAIFF, AudioFormatWave, AudioFormatMP3:
+		return true
+	}
+	return false
+}
+
+// IsLossless reports whether f stores uncompressed audio samples.
+func (f AudioFormat) IsLossless() bool {
+	switch f {
+	case AudioFormatBinary, AudioFormatMotorola, AudioFormatAIFF, AudioFormatWave:
+		return true
+	}
+	return false
+}
+
+// IsCompressed reports whether f stores audio with lossy compression.
+func (f AudioFormat) IsCompressed() bool {
+	return f == AudioFormatMP3
+}
+
+// formatsByExtension maps the lowercase file extensions to the audio format of the FILE command.
+var formatsByExtension = map[string]AudioFormat{
+	".wav":  AudioFormatWave,
+	".mp3":  AudioFormatMP3,
+	".aif":  AudioFormatAIFF,
+	".aiff": AudioFormatAIFF,
+}
+
+// DetectFormat returns the audio format of the file from its extension, ignoring case.
+// It returns ErrUnknownFormat for any other extension.
+func DetectFormat(filename string) (AudioFormat, error) {
+	format, ok := formatsByExtension[strings.ToLower(filepath.Ext(filename))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, filename)
+	}
+	return format, nil
+}
+
+// AutoDetectFormat sets the format of every file of the cue sheet from its name with DetectFormat.
+// It returns an error, leaving the cue sheet unchanged, if a format cannot be inferred.
+func (c *CueSheet) AutoDetectFormat() error {
+	formats := make([]AudioFormat, len(c.Files))
+	for i, file := range c.Files {
+		format, err := DetectFormat(file.FileName)
+		if err != nil {
+			return fmt.Errorf("file %d: %w", i+1, err)
+		}
+		formats[i] = format
+	}
+	for i, format := range formats {
+		c.Files[i].Format = format
+	}
+	return nil
+}
+
+// TrackType is the data type of a track, as declared by the TRACK command.
+type TrackType string
+
+const (
+	TrackTypeAudio     TrackType = "AUDIO"
+	TrackTypeCDG       TrackType = "CDG"
+	TrackTypeMode12048 TrackType = "MODE1/2048"
+	TrackTypeMode12352 TrackType = "MODE1/2352"
+	TrackTypeMode22336 TrackType = "MODE2/2336"
+	TrackTypeMode22352 TrackType = "MODE2/2352"
+	TrackTypeCDI2336   TrackType = "CDI/2336"
+	TrackTypeCDI2352   TrackType = "CDI/2352"
+)
+
+// IsValid reports whether t is one of the track types defined by the cue sheet specification.
+func (t TrackType) IsValid() bool {
+	switch t {
+	case TrackTypeAudio, TrackTypeCDG,
+		TrackTypeMode12048, TrackTypeMode12352,
+		TrackTypeMode22336, TrackTypeMode22352,
+		TrackTypeCDI2336, TrackTypeCDI2352:
+		return true
+	}
+	return false
+}
+
+// IsAudio reports whether t carries audio, i.e. AUDIO or CDG (audio with graphics).
+func (t TrackType) IsAudio() bool {
+	return t == TrackTypeAudio || t == TrackTypeCDG
+}
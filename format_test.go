@@ -0,0 +1,94 @@
+package cuesheetgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tcs := []struct {
+		filename string
+		expected AudioFormat
+	}{
+		{filename: "sample.wav", expected: AudioFormatWave},
+		{filename: "Sample.MP3", expected: AudioFormatMP3},
+		{filename: "sample.aif", expected: AudioFormatAIFF},
+		{filename: "dir.mp3/sample.aiff", expected: AudioFormatAIFF},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.filename, func(t *testing.T) {
+			format, err := DetectFormat(tc.filename)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, format)
+		})
+	}
+
+	for _, filename := range []string{"sample.flac", "sample.ogg", "sample", ""} {
+		_, err := DetectFormat(filename)
+		require.ErrorIs(t, err, ErrUnknownFormat, filename)
+	}
+}
+
+func TestAutoDetectFormat(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	c.Files[0].FileName = "first.mp3"
+	c.Files[1].Format = ""
+	require.NoError(t, c.AutoDetectFormat())
+	require.Equal(t, []FileEntry{{FileName: "first.mp3", Format: AudioFormatMP3}, {FileName: "second.wav", Format: AudioFormatWave}}, c.Files)
+
+	c.Files[0].FileName = "first.ogg"
+	c.Files[1].FileName = "second.aiff"
+	require.EqualError(t, c.AutoDetectFormat(), `file 1: unknown audio format: "first.ogg"`)
+	require.Equal(t, []FileEntry{{FileName: "first.ogg", Format: AudioFormatMP3}, {FileName: "second.aiff", Format: AudioFormatWave}}, c.Files)
+}
+
+func TestAudioFormat(t *testing.T) {
+	tcs := []struct {
+		format     AudioFormat
+		valid      bool
+		lossless   bool
+		compressed bool
+	}{
+		{format: AudioFormatBinary, valid: true, lossless: true},
+		{format: AudioFormatMotorola, valid: true, lossless: true},
+		{format: AudioFormatAIFF, valid: true, lossless: true},
+		{format: AudioFormatWave, valid: true, lossless: true},
+		{format: AudioFormatMP3, valid: true, compressed: true},
+		{format: "FLAC"},
+		{format: ""},
+	}
+	for _, tc := range tcs {
+		t.Run(string(tc.format), func(t *testing.T) {
+			require.Equal(t, string(tc.format), tc.format.String())
+			require.Equal(t, tc.valid, tc.format.Valid())
+			require.Equal(t, tc.lossless, tc.format.IsLossless())
+			require.Equal(t, tc.compressed, tc.format.IsCompressed())
+		})
+	}
+}
+
+func TestTrackType(t *testing.T) {
+	tcs := []struct {
+		typ   TrackType
+		valid bool
+		audio bool
+	}{
+		{typ: TrackTypeAudio, valid: true, audio: true},
+		{typ: TrackTypeCDG, valid: true, audio: true},
+		{typ: TrackTypeMode12048, valid: true},
+		{typ: TrackTypeMode12352, valid: true},
+		{typ: TrackTypeMode22336, valid: true},
+		{typ: TrackTypeMode22352, valid: true},
+		{typ: TrackTypeCDI2336, valid: true},
+		{typ: TrackTypeCDI2352, valid: true},
+		{typ: "VIDEO"},
+		{typ: ""},
+	}
+	for _, tc := range tcs {
+		t.Run(string(tc.typ), func(t *testing.T) {
+			require.Equal(t, tc.valid, tc.typ.IsValid())
+			require.Equal(t, tc.audio, tc.typ.IsAudio())
+		})
+	}
+}
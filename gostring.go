@@ -0,0 +1,61 @@
+package cuesheetgo
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// GoString implements the fmt.GoStringer interface, returning the cue sheet as a Go
+// composite literal without its zero fields.
+func (c CueSheet) GoString() string {
+	return goStringStruct(reflect.ValueOf(c))
+}
+
+// GoString implements the fmt.GoStringer interface, returning the track as a Go
+// composite literal without its zero fields.
+func (t Track) GoString() string {
+	return goStringStruct(reflect.ValueOf(t))
+}
+
+// GoString implements the fmt.GoStringer interface, returning the index point as a Go
+// composite literal with the timestamp in seconds when possible.
+func (idx IndexPoint) GoString() string {
+	timestamp := fmt.Sprint(int64(idx.Timestamp))
+	if idx.Timestamp%time.Second == 0 {
+		timestamp = fmt.Sprintf("%d * time.Second", idx.Timestamp/time.Second)
+	}
+	return fmt.Sprintf("cuesheetgo.IndexPoint{Frame: %d, Timestamp: %s}", idx.Frame, timestamp)
+}
+
+// goStringStruct formats the struct v as a composite literal, skipping the zero fields.
+func goStringStruct(v reflect.Value) string {
+	var fields []string
+	for i := range v.NumField() {
+		if field := v.Field(i); !field.IsZero() {
+			fields = append(fields, v.Type().Field(i).Name+": "+goStringValue(field))
+		}
+	}
+	return v.Type().String() + "{" + strings.Join(fields, ", ") + "}"
+}
+
+func goStringValue(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.Elem().Kind() != reflect.Struct {
+			// Only composite literals can be addressed, other values need a variable.
+			value := fmt.Sprintf("%s(%#v)", v.Elem().Type(), v.Elem().Interface())
+			return fmt.Sprintf("func() %s { v := %s; return &v }()", v.Type(), value)
+		}
+		return "&" + goStringValue(v.Elem())
+	case reflect.Slice:
+		elements := make([]string, v.Len())
+		for i := range elements {
+			elements[i] = goStringValue(v.Index(i))
+		}
+		return v.Type().String() + "{" + strings.Join(elements, ", ") + "}"
+	default:
+		return fmt.Sprintf("%#v", v.Interface())
+	}
+}
@@ -0,0 +1,31 @@
+package cuesheetgo
+
+import (
+	"fmt"
+	goparser "go/parser"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoString(t *testing.T) {
+	c := allCueSheet.Clone()
+	c.Tracks[1].Index00 = &IndexPoint{Timestamp: 50 * time.Second, Frame: 3}
+	c.Remarks = []string{`GENERATOR "Some Ripper"`}
+	c.DiscID = &discID
+
+	for _, v := range []any{*c, c} {
+		s := fmt.Sprintf("%#v", v)
+		require.Contains(t, s, "cuesheetgo.CueSheet{")
+		require.Contains(t, s, `Remarks: []string{"GENERATOR \"Some Ripper\""}`)
+		require.Contains(t, s, "Index00: &cuesheetgo.IndexPoint{Frame: 3, Timestamp: 50 * time.Second}")
+		require.Contains(t, s, "DiscID: func() *uint32 { v := uint32(0x860b640b); return &v }()")
+		require.NotContains(t, s, "AlbumGain")
+		_, err := goparser.ParseExpr(s)
+		require.NoError(t, err, s)
+	}
+
+	require.Equal(t, `cuesheetgo.Track{Number: 2, Type: "AUDIO"}`, fmt.Sprintf("%#v", Track{Number: 2, Type: TrackTypeAudio}))
+	require.Equal(t, "cuesheetgo.IndexPoint{Frame: 0, Timestamp: 1500000000}", fmt.Sprintf("%#v", IndexPoint{Timestamp: 1500 * time.Millisecond}))
+}
@@ -0,0 +1,71 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidEAN is returned when a catalog number is not a valid EAN-13 code.
+var ErrInvalidEAN = errors.New("invalid EAN-13")
+
+// isrcRegexp matches an International Standard Recording Code: a 2-letter country code,
+// a 3-character registrant code, a 2-digit year and a 5-digit serial number.
+var isrcRegexp = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+
+// ValidateISRC reports whether isrc is a well-formed International Standard Recording Code,
+// such as "USRC17607839".
+func ValidateISRC(isrc string) bool {
+	return isrcRegexp.MatchString(isrc)
+}
+
+// catalogLength is the number of digits of a Media Catalog Number.
+const catalogLength = 13
+
+// ValidateCatalog reports whether mcn is a well-formed Media Catalog Number:
+// 13 decimal digits, the last one being the EAN-13 check digit.
+func ValidateCatalog(mcn string) bool {
+	return isCatalogFormat(mcn) && catalogCheckDigit(mcn) == int(mcn[catalogLength-1]-'0')
+}
+
+// isCatalogFormat reports whether mcn is made of exactly 13 decimal digits.
+func isCatalogFormat(mcn string) bool {
+	return len(mcn) == catalogLength && isDigits(mcn)
+}
+
+func isDigits(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) == -1
+}
+
+// catalogCheckDigit returns the EAN-13 check digit computed from the first 12 digits of mcn.
+func catalogCheckDigit(mcn string) int {
+	var sum int
+	for i := range catalogLength - 1 {
+		digit := int(mcn[i] - '0')
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return (10 - sum%10) % 10
+}
+
+// EAN13CheckDigit returns the EAN-13 check digit of the first 12 digits of a code, as the
+// ASCII character to append to them. It returns an error if digits is not made of exactly
+// 12 decimal digits.
+func EAN13CheckDigit(digits string) (byte, error) {
+	if len(digits) != catalogLength-1 || !isDigits(digits) {
+		return 0, fmt.Errorf("expected %d decimal digits, got %q", catalogLength-1, digits)
+	}
+	return byte('0' + catalogCheckDigit(digits)), nil
+}
+
+// EAN returns the catalog number of the cue sheet as an EAN-13 code.
+// It returns ErrInvalidEAN if the catalog number is empty, malformed or has a wrong check digit.
+func (c *CueSheet) EAN() (string, error) {
+	if !ValidateCatalog(c.Catalog) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidEAN, c.Catalog)
+	}
+	return c.Catalog, nil
+}
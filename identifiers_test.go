@@ -0,0 +1,60 @@
+package cuesheetgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCatalog(t *testing.T) {
+	for _, mcn := range []string{"0000000000000", "4006381333931", "0724384960650"} {
+		require.True(t, ValidateCatalog(mcn), mcn)
+	}
+	tcs := map[string]string{
+		"Empty":          "",
+		"TooShort":       "400638133393",
+		"TooLong":        "40063813339310",
+		"NonDigit":       "400638133393X",
+		"NonASCIIDigit":  "40063813339٣",
+		"BadCheckDigit":  "4006381333932",
+		"Whitespace":     " 400638133393",
+		"NegativeNumber": "-400638133393",
+	}
+	for name, mcn := range tcs {
+		require.False(t, ValidateCatalog(mcn), name)
+	}
+}
+
+func TestEAN13CheckDigit(t *testing.T) {
+	for _, ean := range []string{"0000000000000", "4006381333931", "0724384960650", "5901234123457"} {
+		digit, err := EAN13CheckDigit(ean[:12])
+		require.NoError(t, err, ean)
+		require.Equal(t, ean[12], digit, ean)
+	}
+	for _, digits := range []string{"", "40063813339", "4006381333931", "40063813339X", "40063813339٣"} {
+		_, err := EAN13CheckDigit(digits)
+		require.ErrorContains(t, err, "expected 12 decimal digits", digits)
+	}
+}
+
+func TestEAN(t *testing.T) {
+	ean, err := catalogCueSheet.EAN()
+	require.NoError(t, err)
+	require.Equal(t, "4006381333931", ean)
+
+	for _, catalog := range []string{"", "4006381333932", "400638133393", "400638133393X"} {
+		c := &CueSheet{Catalog: catalog}
+		ean, err := c.EAN()
+		require.ErrorIs(t, err, ErrInvalidEAN, catalog)
+		require.Empty(t, ean)
+	}
+}
+
+func TestValidateISRC(t *testing.T) {
+	for _, isrc := range []string{"USRC17607839", "GBAYE0601498", "FR6V81234567"} {
+		require.True(t, ValidateISRC(isrc), isrc)
+	}
+	for _, isrc := range []string{"", "USRC1760783", "USRC176078390", "usrc17607839", "US-RC1760783", "USRC1760783X", "1SRC17607839"} {
+		require.False(t, ValidateISRC(isrc), isrc)
+	}
+}
@@ -0,0 +1,149 @@
+package cuesheetgo
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// framesPerSecond is the number of CD frames (sectors) in one second of audio.
+const framesPerSecond = 75
+
+// IndexPoint represents a position in the audio file in the MM:SS:FF format.
+// Timestamp holds the minutes and seconds, Frame holds the remaining frames.
+type IndexPoint struct {
+	Frame     int
+	Timestamp time.Duration
+}
+
+// indexPointRegexp matches an index point written as MM:SS:FF, with two digits each.
+var indexPointRegexp = regexp.MustCompile(`^[0-9]{2}:[0-9]{2}:[0-9]{2}$`)
+
+// ParseIndexPoint parses an index point in the MM:SS:FF format, with two digits each.
+func ParseIndexPoint(s string) (IndexPoint, error) {
+	if !indexPointRegexp.MatchString(s) {
+		return IndexPoint{}, fmt.Errorf("error parsing timestamp and frame: expected MM:SS:FF, got %q", s)
+	}
+	var minutes, seconds, frames int
+	if _, err := fmt.Sscanf(s, "%2d:%2d:%2d", &minutes, &seconds, &frames); err != nil {
+		return IndexPoint{}, fmt.Errorf("error parsing timestamp and frame: %w", err)
+	}
+	if seconds < 0 || seconds >= 60 {
+		return IndexPoint{}, fmt.Errorf("seconds out of range [0, 59]: %d", seconds)
+	}
+	if frames < 0 || frames >= framesPerSecond {
+		return IndexPoint{}, fmt.Errorf("frames out of range [0, %d]: %d", framesPerSecond-1, frames)
+	}
+	duration := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	return IndexPoint{Timestamp: duration, Frame: frames}, nil
+}
+
+// IndexPointFromFrames returns the IndexPoint located at the given absolute frame count.
+// Frames beyond a full second are carried into the timestamp.
+func IndexPointFromFrames(frames int) IndexPoint {
+	return IndexPoint{
+		Frame:     frames % framesPerSecond,
+		Timestamp: time.Duration(frames/framesPerSecond) * time.Second,
+	}
+}
+
+// Normalize returns idx in canonical form, with the frames overflowing a second carried into
+// the timestamp, Frame in [0, 74] and the timestamp truncated to whole seconds.
+// The index point must be at or after 00:00:00.
+func (idx IndexPoint) Normalize() IndexPoint {
+	return IndexPointFromFrames(idx.AbsoluteFrames())
+}
+
+// IsZero reports whether idx is the zero IndexPoint, i.e. 00:00:00.
+func (idx IndexPoint) IsZero() bool {
+	return idx == IndexPoint{}
+}
+
+// AbsoluteFrames returns the total number of frames from the start of the file to the index point.
+func (idx IndexPoint) AbsoluteFrames() int {
+	return int(idx.Timestamp.Minutes())*60*framesPerSecond + int(idx.Timestamp.Seconds())%60*framesPerSecond + idx.Frame
+}
+
+// Add returns idx moved by d, truncated to whole frames. Frames and seconds overflowing
+// their range are carried. Add panics if a negative d would move idx before 00:00:00,
+// use Sub to move an index point backward safely.
+func (idx IndexPoint) Add(d time.Duration) IndexPoint {
+	frames := idx.AbsoluteFrames() + DurationToFrames(d)
+	if frames < 0 {
+		panic(fmt.Sprintf("adding %s to %s results in a negative index", d, idx))
+	}
+	return IndexPointFromFrames(frames)
+}
+
+// Sub returns idx moved backward by d, truncated to whole frames.
+// It returns an error if the result would be before 00:00:00.
+func (idx IndexPoint) Sub(d time.Duration) (IndexPoint, error) {
+	frames := idx.AbsoluteFrames() - DurationToFrames(d)
+	if frames < 0 {
+		return IndexPoint{}, fmt.Errorf("subtracting %s from %s results in a negative index", d, idx)
+	}
+	return IndexPointFromFrames(frames), nil
+}
+
+// AddIndexPoint returns the sum of idx and other, carrying overflowing frames and seconds.
+func (idx IndexPoint) AddIndexPoint(other IndexPoint) IndexPoint {
+	return IndexPointFromFrames(idx.AbsoluteFrames() + other.AbsoluteFrames())
+}
+
+// LessThan reports whether idx is before other.
+func (idx IndexPoint) LessThan(other IndexPoint) bool {
+	return idx.Timestamp < other.Timestamp || (idx.Timestamp == other.Timestamp && idx.Frame < other.Frame)
+}
+
+// LessThanOrEqual reports whether idx is before or at other.
+func (idx IndexPoint) LessThanOrEqual(other IndexPoint) bool {
+	return !other.LessThan(idx)
+}
+
+// GreaterThan reports whether idx is after other.
+func (idx IndexPoint) GreaterThan(other IndexPoint) bool {
+	return other.LessThan(idx)
+}
+
+// GreaterThanOrEqual reports whether idx is after or at other.
+func (idx IndexPoint) GreaterThanOrEqual(other IndexPoint) bool {
+	return !idx.LessThan(other)
+}
+
+// FrameToDuration converts a number of frames into the equivalent duration.
+// The result is rounded away from zero to the nanosecond, so that DurationToFrames
+// converts it back to the same number of frames.
+func FrameToDuration(frames int) time.Duration {
+	d := time.Duration(frames) * time.Second
+	if d < 0 {
+		return -((-d + framesPerSecond - 1) / framesPerSecond)
+	}
+	return (d + framesPerSecond - 1) / framesPerSecond
+}
+
+// DurationToFrames converts a duration into the number of whole frames it spans.
+func DurationToFrames(d time.Duration) int {
+	return int(d * framesPerSecond / time.Second)
+}
+
+// String returns the index point in the MM:SS:FF format.
+func (idx IndexPoint) String() string {
+	minutes := int(idx.Timestamp / time.Minute)
+	seconds := int(idx.Timestamp % time.Minute / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", minutes, seconds, idx.Frame)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (idx IndexPoint) MarshalText() ([]byte, error) {
+	return []byte(idx.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (idx *IndexPoint) UnmarshalText(text []byte) error {
+	index, err := ParseIndexPoint(string(text))
+	if err != nil {
+		return err
+	}
+	*idx = index
+	return nil
+}
@@ -0,0 +1,187 @@
+package cuesheetgo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexPointIsZero(t *testing.T) {
+	require.True(t, IndexPoint{}.IsZero())
+	require.False(t, IndexPoint{Frame: 1}.IsZero())
+	require.False(t, IndexPoint{Timestamp: time.Second}.IsZero())
+}
+
+func TestIndexPointAbsoluteFrames(t *testing.T) {
+	tcs := []struct {
+		name     string
+		index    IndexPoint
+		expected int
+	}{
+		{name: "Zero", index: IndexPoint{}, expected: 0},
+		{name: "FramesOnly", index: IndexPoint{Frame: 74}, expected: 74},
+		{name: "OneSecond", index: IndexPoint{Timestamp: time.Second}, expected: 75},
+		{name: "OneMinute", index: IndexPoint{Timestamp: time.Minute}, expected: 4500},
+		{name: "Mixed", index: IndexPoint{Timestamp: 2*time.Minute + 3*time.Second, Frame: 4}, expected: 9229},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.index.AbsoluteFrames())
+		})
+	}
+}
+
+func TestIndexPointFromFrames(t *testing.T) {
+	tcs := []struct {
+		name     string
+		frames   int
+		expected IndexPoint
+	}{
+		{name: "Zero", frames: 0, expected: IndexPoint{}},
+		{name: "NoCarry", frames: 74, expected: IndexPoint{Frame: 74}},
+		{name: "CarryIntoSeconds", frames: 75, expected: IndexPoint{Timestamp: time.Second}},
+		{name: "CarryIntoMinutes", frames: 4501, expected: IndexPoint{Timestamp: time.Minute, Frame: 1}},
+		{name: "Mixed", frames: 9229, expected: IndexPoint{Timestamp: 2*time.Minute + 3*time.Second, Frame: 4}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			index := IndexPointFromFrames(tc.frames)
+			require.Equal(t, tc.expected, index)
+			require.Equal(t, tc.frames, index.AbsoluteFrames())
+		})
+	}
+}
+
+func TestIndexPointNormalize(t *testing.T) {
+	tcs := []struct {
+		name     string
+		index    IndexPoint
+		expected IndexPoint
+	}{
+		{name: "Zero", index: IndexPoint{}, expected: IndexPoint{}},
+		{name: "Canonical", index: IndexPoint{Timestamp: time.Minute + 59*time.Second, Frame: 74}, expected: IndexPoint{Timestamp: time.Minute + 59*time.Second, Frame: 74}},
+		{name: "OneSecond", index: IndexPoint{Frame: 75}, expected: IndexPoint{Timestamp: time.Second}},
+		{name: "TwoSeconds", index: IndexPoint{Frame: 150}, expected: IndexPoint{Timestamp: 2 * time.Second}},
+		{name: "CarryIntoMinutes", index: IndexPoint{Timestamp: 59 * time.Second, Frame: 80}, expected: IndexPoint{Timestamp: time.Minute, Frame: 5}},
+		{name: "NegativeFrame", index: IndexPoint{Timestamp: 2 * time.Second, Frame: -1}, expected: IndexPoint{Timestamp: time.Second, Frame: 74}},
+		{name: "SubSecondTimestamp", index: IndexPoint{Timestamp: 1500 * time.Millisecond, Frame: 3}, expected: IndexPoint{Timestamp: time.Second, Frame: 3}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.index.Normalize())
+			require.Equal(t, tc.expected, tc.expected.Normalize())
+		})
+	}
+}
+
+func TestIndexPointArithmetic(t *testing.T) {
+	idx := IndexPoint{Timestamp: time.Minute + 59*time.Second, Frame: 70}
+
+	t.Run("Add", func(t *testing.T) {
+		require.Equal(t, IndexPoint{Timestamp: time.Minute + 59*time.Second, Frame: 74}, idx.Add(FrameToDuration(4)))
+		require.Equal(t, IndexPoint{Timestamp: 2 * time.Minute, Frame: 5}, idx.Add(FrameToDuration(10)))
+		require.Equal(t, IndexPoint{Timestamp: 2*time.Minute + 2*time.Second, Frame: 70}, idx.Add(3*time.Second))
+		require.Equal(t, IndexPoint{Timestamp: time.Minute + 58*time.Second, Frame: 70}, idx.Add(-time.Second))
+		require.Equal(t, idx, idx.Add(time.Millisecond))
+		require.Panics(t, func() { idx.Add(-2 * time.Minute) })
+	})
+	t.Run("Sub", func(t *testing.T) {
+		sub, err := idx.Sub(FrameToDuration(71))
+		require.NoError(t, err)
+		require.Equal(t, IndexPoint{Timestamp: time.Minute + 58*time.Second, Frame: 74}, sub)
+
+		sub, err = idx.Sub(idx.Timestamp + FrameToDuration(idx.Frame))
+		require.NoError(t, err)
+		require.True(t, sub.IsZero())
+
+		_, err = idx.Sub(2 * time.Minute)
+		require.ErrorContains(t, err, "subtracting 2m0s from 01:59:70 results in a negative index")
+	})
+	t.Run("AddIndexPoint", func(t *testing.T) {
+		require.Equal(t, IndexPoint{Timestamp: 2*time.Minute + 3*time.Second, Frame: 5}, idx.AddIndexPoint(IndexPoint{Timestamp: 3 * time.Second, Frame: 10}))
+		require.Equal(t, idx, idx.AddIndexPoint(IndexPoint{}))
+	})
+}
+
+func TestIndexPointComparison(t *testing.T) {
+	tcs := []struct {
+		name string
+		a, b IndexPoint
+		less bool
+	}{
+		{name: "Zero", a: IndexPoint{}, b: IndexPoint{}},
+		{name: "Equal", a: IndexPoint{Timestamp: time.Minute, Frame: 3}, b: IndexPoint{Timestamp: time.Minute, Frame: 3}},
+		{name: "EqualTimestamps", a: IndexPoint{Timestamp: time.Minute, Frame: 3}, b: IndexPoint{Timestamp: time.Minute, Frame: 4}, less: true},
+		{name: "EqualFrames", a: IndexPoint{Timestamp: time.Second, Frame: 3}, b: IndexPoint{Timestamp: time.Minute, Frame: 3}, less: true},
+		{name: "LastFrame", a: IndexPoint{Frame: 74}, b: IndexPoint{Timestamp: time.Second}, less: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			equal := tc.a == tc.b
+			require.Equal(t, tc.less, tc.a.LessThan(tc.b))
+			require.Equal(t, tc.less || equal, tc.a.LessThanOrEqual(tc.b))
+			require.False(t, tc.a.GreaterThan(tc.b))
+			require.Equal(t, equal, tc.a.GreaterThanOrEqual(tc.b))
+
+			require.False(t, tc.b.LessThan(tc.a))
+			require.Equal(t, tc.less, tc.b.GreaterThan(tc.a))
+			require.True(t, tc.b.GreaterThanOrEqual(tc.a))
+		})
+	}
+}
+
+func TestFrameDurationConversion(t *testing.T) {
+	require.Equal(t, time.Duration(0), FrameToDuration(0))
+	require.Equal(t, time.Second, FrameToDuration(75))
+	require.Equal(t, time.Minute, FrameToDuration(4500))
+	require.Equal(t, 40*time.Millisecond, FrameToDuration(3))
+
+	require.Equal(t, 0, DurationToFrames(0))
+	require.Equal(t, 75, DurationToFrames(time.Second))
+	require.Equal(t, 4500, DurationToFrames(time.Minute))
+	require.Equal(t, 3, DurationToFrames(40*time.Millisecond))
+
+	for _, frames := range []int{-71, -4, 1, 4, 71, 4501} {
+		require.Equal(t, frames, DurationToFrames(FrameToDuration(frames)), "frames %d", frames)
+	}
+}
+
+func TestParseIndexPoint(t *testing.T) {
+	index, err := ParseIndexPoint("02:03:04")
+	require.NoError(t, err)
+	require.Equal(t, IndexPoint{Timestamp: 2*time.Minute + 3*time.Second, Frame: 4}, index)
+
+	for _, s := range []string{"AA:BB:CC", "01:02:03xyz", "01:02:0345", "1:2:3", " 1:02:03", "-1:02:03", "01-02-03", ""} {
+		_, err = ParseIndexPoint(s)
+		require.EqualError(t, err, fmt.Sprintf("error parsing timestamp and frame: expected MM:SS:FF, got %q", s))
+	}
+
+	_, err = ParseIndexPoint("00:60:00")
+	require.ErrorContains(t, err, "seconds out of range [0, 59]: 60")
+
+	_, err = ParseIndexPoint("00:00:75")
+	require.ErrorContains(t, err, "frames out of range [0, 74]: 75")
+}
+
+func TestIndexPointString(t *testing.T) {
+	require.Equal(t, "00:00:00", IndexPoint{}.String())
+	require.Equal(t, "02:03:04", IndexPoint{Timestamp: 2*time.Minute + 3*time.Second, Frame: 4}.String())
+	require.Equal(t, "99:59:74", IndexPoint{Timestamp: 99*time.Minute + 59*time.Second, Frame: 74}.String())
+}
+
+func TestIndexPointTextMarshaling(t *testing.T) {
+	index := IndexPoint{Timestamp: 12*time.Minute + 34*time.Second, Frame: 56}
+
+	text, err := index.MarshalText()
+	require.NoError(t, err)
+	require.Equal(t, "12:34:56", string(text))
+
+	var unmarshaled IndexPoint
+	require.NoError(t, unmarshaled.UnmarshalText(text))
+	require.Equal(t, index, unmarshaled)
+
+	require.Error(t, unmarshaled.UnmarshalText([]byte("invalid")))
+	require.Equal(t, index, unmarshaled)
+}
@@ -0,0 +1,99 @@
+package cuesheetgo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// jsonCueSheet has the fields of CueSheet without its methods, so that it is
+// encoded as a JSON object instead of through CueSheet.MarshalText.
+type jsonCueSheet CueSheet
+
+// jsonDocument is the JSON form of a cue sheet, with the disc ID as its hexadecimal string.
+type jsonDocument struct {
+	*jsonCueSheet
+	DiscID string `json:"disc_id,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (c *CueSheet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonDocument{jsonCueSheet: (*jsonCueSheet)(c), DiscID: c.DiscIDHex()})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (c *CueSheet) UnmarshalJSON(data []byte) error {
+	var parsed CueSheet
+	doc := jsonDocument{jsonCueSheet: (*jsonCueSheet)(&parsed)}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if doc.DiscID != "" {
+		if err := parsed.parseDiscID(doc.DiscID); err != nil {
+			return err
+		}
+	}
+	*c = parsed
+	return nil
+}
+
+// ToMap returns every field of the cue sheet under its JSON name, for template engines
+// and scripting layers. Files and tracks are returned as maps too, index points as MM:SS:FF strings
+// and typed strings such as the audio format as plain strings. The disc ID is its hexadecimal string,
+// as in the JSON form.
+func (c *CueSheet) ToMap() map[string]any {
+	m := structToMap(reflect.ValueOf(*c))
+	if c.DiscID != nil {
+		m["disc_id"] = c.DiscIDHex()
+	}
+	return m
+}
+
+func structToMap(v reflect.Value) map[string]any {
+	m := make(map[string]any, v.NumField())
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		m[name] = mapValue(v.Field(i))
+	}
+	return m
+}
+
+func mapValue(v reflect.Value) any {
+	switch value := v.Interface().(type) {
+	case IndexPoint:
+		return value.String()
+	case *IndexPoint:
+		if value == nil {
+			return nil
+		}
+		return value.String()
+	case []IndexPoint:
+		indices := make([]string, len(value))
+		for i, idx := range value {
+			indices[i] = idx.String()
+		}
+		return indices
+	case []FileEntry:
+		files := make([]map[string]any, len(value))
+		for i, file := range value {
+			files[i] = structToMap(reflect.ValueOf(file))
+		}
+		return files
+	case TrackList:
+		tracks := make([]map[string]any, len(value))
+		for i, track := range value {
+			tracks[i] = structToMap(reflect.ValueOf(track))
+		}
+		return tracks
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return mapValue(v.Elem())
+	case reflect.String:
+		return v.String()
+	}
+	return v.Interface()
+}
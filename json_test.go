@@ -0,0 +1,75 @@
+package cuesheetgo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONMarshaling(t *testing.T) {
+	data, err := json.Marshal(&allCueSheet)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"album_performer": "Sample Album Artist",
+		"album_title": "Sample Album",
+		"files": [{"file_name": "sample.flac", "audio_format": "WAVE"}],
+		"tracks": [
+			{"number": 1, "type": "AUDIO", "title": "First Track", "index01": "00:01:00"},
+			{"number": 2, "type": "AUDIO", "title": "Second Track", "index01": "01:00:00"}
+		]
+	}`, string(data))
+
+	var unmarshaled CueSheet
+	require.NoError(t, json.Unmarshal(data, &unmarshaled))
+	require.Equal(t, allCueSheet, unmarshaled)
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	for _, cueSheet := range []CueSheet{replayGainCueSheet, commentsCueSheet, multipleIndicesCueSheet, discNumberCueSheet, multipleFilesCueSheet, discIDCueSheet} {
+		data, err := json.Marshal(&cueSheet)
+		require.NoError(t, err)
+
+		var unmarshaled CueSheet
+		require.NoError(t, json.Unmarshal(data, &unmarshaled))
+		require.Equal(t, cueSheet, unmarshaled)
+	}
+}
+
+func TestJSONDiscID(t *testing.T) {
+	data, err := json.Marshal(&discIDCueSheet)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"disc_id":"860B640B"`)
+
+	var unmarshaled CueSheet
+	require.EqualError(t, json.Unmarshal([]byte(`{"disc_id": "860B640"}`), &unmarshaled), `invalid disc ID, expected 8 hexadecimal digits: "860B640"`)
+}
+
+func TestToMap(t *testing.T) {
+	c := multipleIndicesCueSheet.Clone()
+	c.AlbumTitle = "Sample Album"
+	c.TotalLength = &IndexPoint{Timestamp: 3 * time.Minute}
+
+	m := c.ToMap()
+	require.Equal(t, "Sample Album", m["album_title"])
+	require.Equal(t, []map[string]any{{"file_name": "sample.flac", "audio_format": "WAVE"}}, m["files"])
+	require.Equal(t, "03:00:00", m["total_length"])
+	require.Equal(t, 0, m["disc_number"])
+	require.Contains(t, m, "remarks")
+
+	tracks, ok := m["tracks"].([]map[string]any)
+	require.True(t, ok)
+	require.Len(t, tracks, len(c.Tracks))
+	require.Equal(t, 2, tracks[1]["number"])
+	require.Equal(t, "AUDIO", tracks[1]["type"])
+	require.Equal(t, c.Tracks[1].Index00.String(), tracks[1]["index00"])
+	require.Equal(t, c.Tracks[1].Index01.String(), tracks[1]["index01"])
+	require.Nil(t, tracks[0]["index00"])
+	require.IsType(t, []string{}, tracks[1]["indices"])
+
+	m = allCueSheet.ToMap()
+	require.Equal(t, "Sample Album Artist", m["album_performer"])
+	require.Equal(t, "First Track", m["tracks"].([]map[string]any)[0]["title"])
+	require.Equal(t, "00:01:00", m["tracks"].([]map[string]any)[0]["index01"])
+}
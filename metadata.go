@@ -0,0 +1,68 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// ErrNoYear is returned by Year when the date of the cue sheet contains no year.
+var ErrNoYear = errors.New("no year found in date")
+
+// ErrUnparsedDate is returned by ParsedDate when the date of the cue sheet has no supported layout.
+var ErrUnparsedDate = errors.New("unparsed date")
+
+// dateLayouts are the REM DATE layouts supported by ParsedDate.
+var dateLayouts = []string{"2006", "2006-01", "2006-01-02"}
+
+// yearRegexp matches a 4-digit year that is not part of a longer number,
+// as in "1989", "1989-07", "1989-07-14" or "July 1989".
+var yearRegexp = regexp.MustCompile(`(?:^|\D)(\d{4})(?:\D|$)`)
+
+// Year returns the release year found in the REM DATE value of the cue sheet.
+func (c *CueSheet) Year() (int, error) {
+	match := yearRegexp.FindStringSubmatch(c.Date)
+	if match == nil {
+		return 0, ErrNoYear
+	}
+	return strconv.Atoi(match[1])
+}
+
+// ParsedDate returns the REM DATE value of the cue sheet as a time, when it is a year,
+// a year and month (2006-01) or a full date (2006-01-02).
+func (c *CueSheet) ParsedDate() (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, c.Date); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, ErrUnparsedDate
+}
+
+// DiscIDHex returns the CDDB disc ID as 8 uppercase hexadecimal digits, as written in
+// REM DISCID, or an empty string when the cue sheet has no disc ID.
+func (c *CueSheet) DiscIDHex() string {
+	if c.DiscID == nil {
+		return ""
+	}
+	return fmt.Sprintf("%08X", *c.DiscID)
+}
+
+// AllPerformers returns the album performer followed by the track performers,
+// without empty values and duplicates, in the order they first appear.
+func (c *CueSheet) AllPerformers() []string {
+	performers := []string{}
+	add := func(performer string) {
+		if performer != "" && !slices.Contains(performers, performer) {
+			performers = append(performers, performer)
+		}
+	}
+	add(c.AlbumPerformer)
+	for _, track := range c.Tracks {
+		add(track.Performer)
+	}
+	return performers
+}
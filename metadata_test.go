@@ -0,0 +1,98 @@
+package cuesheetgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestYear(t *testing.T) {
+	for _, date := range []string{"1989", "1989-07", "1989-07-14", "July 1989", "14/07/1989"} {
+		t.Run(date, func(t *testing.T) {
+			c := &CueSheet{Date: date}
+			year, err := c.Year()
+			require.NoError(t, err)
+			require.Equal(t, 1989, year)
+		})
+	}
+	for _, date := range []string{"", "July", "89", "19890714"} {
+		t.Run("Invalid"+date, func(t *testing.T) {
+			c := &CueSheet{Date: date}
+			year, err := c.Year()
+			require.ErrorIs(t, err, ErrNoYear)
+			require.Zero(t, year)
+		})
+	}
+}
+
+func TestParsedDate(t *testing.T) {
+	tcs := []struct {
+		date     string
+		expected time.Time
+	}{
+		{date: "1989", expected: time.Date(1989, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{date: "1989-07", expected: time.Date(1989, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		{date: "1989-07-14", expected: time.Date(1989, time.July, 14, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.date, func(t *testing.T) {
+			date, err := (&CueSheet{Date: tc.date}).ParsedDate()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, date)
+		})
+	}
+	for _, date := range []string{"", "July 1989", "1989-13", "1989-02-30"} {
+		t.Run("Invalid"+date, func(t *testing.T) {
+			parsed, err := (&CueSheet{Date: date}).ParsedDate()
+			require.ErrorIs(t, err, ErrUnparsedDate)
+			require.Zero(t, parsed)
+		})
+	}
+}
+
+func TestDiscIDHex(t *testing.T) {
+	c, err := Parse(strings.NewReader("REM DISCID 0AB12345\nFILE sample.flac WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:00\n"))
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x0AB12345), *c.DiscID)
+	require.Equal(t, "0AB12345", c.DiscIDHex())
+
+	require.Equal(t, "860B640B", discIDCueSheet.DiscIDHex())
+	require.Empty(t, (&CueSheet{}).DiscIDHex())
+}
+
+func TestAllPerformers(t *testing.T) {
+	tcs := []struct {
+		name     string
+		cueSheet CueSheet
+		expected []string
+	}{
+		{
+			name: "Same",
+			cueSheet: CueSheet{
+				AlbumPerformer: "Artist",
+				Tracks:         []Track{{Performer: "Artist"}, {Performer: "Artist"}},
+			},
+			expected: []string{"Artist"},
+		},
+		{
+			name: "Different",
+			cueSheet: CueSheet{
+				AlbumPerformer: "Various Artists",
+				Tracks:         []Track{{Performer: "First"}, {}, {Performer: "Second"}, {Performer: "First"}},
+			},
+			expected: []string{"Various Artists", "First", "Second"},
+		},
+		{
+			name:     "None",
+			cueSheet: CueSheet{Tracks: []Track{{}, {}}},
+			expected: []string{},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.cueSheet.AllPerformers())
+		})
+	}
+}
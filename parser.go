@@ -0,0 +1,270 @@
+package cuesheetgo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Event is a cue sheet entity returned by Parser.Next. It is one of FileEvent,
+// PerformerEvent, TitleEvent, CatalogEvent, TrackEvent, ISRCEvent, IndexEvent or RemEvent.
+type Event interface {
+	isEvent()
+}
+
+// FileEvent is returned for a FILE command.
+type FileEvent struct {
+	FileName string
+	Format   AudioFormat
+}
+
+// PerformerEvent is returned for a PERFORMER command.
+type PerformerEvent struct {
+	// Track is the number of the track the performer belongs to, 0 for the album.
+	Track     int
+	Performer string
+}
+
+// TitleEvent is returned for a TITLE command.
+type TitleEvent struct {
+	// Track is the number of the track the title belongs to, 0 for the album.
+	Track int
+	Title string
+}
+
+// CatalogEvent is returned for a CATALOG command.
+type CatalogEvent struct {
+	Catalog string
+}
+
+// TrackEvent is returned for a TRACK command.
+type TrackEvent struct {
+	Number int
+	Type   TrackType
+}
+
+// ISRCEvent is returned for an ISRC command.
+type ISRCEvent struct {
+	// Track is the number of the track the ISRC belongs to.
+	Track int
+	ISRC  string
+}
+
+// IndexEvent is returned for an INDEX command.
+type IndexEvent struct {
+	// Track is the number of the track the index belongs to.
+	Track  int
+	Number int
+	Point  IndexPoint
+}
+
+// RemEvent is returned for a REM command, known or not.
+type RemEvent struct {
+	// Track is the number of the track the remark follows, 0 before the first track.
+	Track int
+	Key   string
+	Value string
+}
+
+func (FileEvent) isEvent()      {}
+func (PerformerEvent) isEvent() {}
+func (TitleEvent) isEvent()     {}
+func (CatalogEvent) isEvent()   {}
+func (TrackEvent) isEvent()     {}
+func (ISRCEvent) isEvent()      {}
+func (IndexEvent) isEvent()     {}
+func (RemEvent) isEvent()       {}
+
+// defaultMaxLineLength is the maximum length of a line when ParseOptions.MaxLineLength is not set.
+const defaultMaxLineLength = 1 << 20
+
+// ParseError is returned when a line of the cue sheet cannot be parsed.
+type ParseError struct {
+	// Line is the 1-based number of the offending line.
+	Line int
+	// Text is the offending line without surrounding whitespace, empty when it could not be read.
+	Text string
+	// ByteOffset is the offset of the start of the offending line from the start of the input,
+	// set only with ParseOptions.TrackByteOffsets.
+	ByteOffset int64
+	Err        error
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("line %d:\n\t%v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("line %d:\t%s:\n\t%v", e.Line, e.Text, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parser reads a cue sheet one command at a time.
+// It keeps the state needed to check that the commands are consistent, such as the
+// track and index numbers, and validates the whole cue sheet at the end of the input.
+type Parser struct {
+	scanner *bufio.Scanner
+	opts    ParseOptions
+	logger  *slog.Logger
+	c       *CueSheet
+	lineNr  int
+	// lineOffset is the byte offset of the last line read, consumed the number of bytes read
+	// up to the end of that line. They are only updated with ParseOptions.TrackByteOffsets.
+	lineOffset, consumed int64
+	// lastTrack is the number of the last TRACK parsed, as written in the cue sheet.
+	lastTrack int
+	// lastIndex is the number of the last INDEX parsed in the current track.
+	lastIndex int
+	// skipTrack is set while the lines of a TRACK skipped as invalid are skipped with it.
+	skipTrack bool
+	// fields holds the fields of the current line, reused from one line to the next.
+	fields []string
+	// err is the error returned by every call to Next after the first failure.
+	err error
+}
+
+// NewParser returns a Parser reading the cue sheet from reader.
+func NewParser(reader io.Reader) *Parser {
+	return NewParserWithOptions(reader, ParseOptions{})
+}
+
+// NewParserWithOptions is like NewParser but allows configuring the parser through opts.
+func NewParserWithOptions(reader io.Reader, opts ParseOptions) *Parser {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	p := &Parser{
+		scanner:   bufio.NewScanner(reader),
+		opts:      opts,
+		logger:    logger,
+		c:         &CueSheet{Tracks: []Track{}},
+		lastIndex: noIndex,
+	}
+	// The buffer must also hold the line ending to find the end of a line of maximum length.
+	maxTokenSize := p.maxLineLength() + len("\r\n")
+	p.scanner.Buffer(make([]byte, 0, min(bufio.MaxScanTokenSize, maxTokenSize)), maxTokenSize)
+	if opts.TrackByteOffsets {
+		p.scanner.Split(p.scanLines)
+	}
+	return p
+}
+
+// scanLines is bufio.ScanLines keeping track of the byte offset of every line.
+// The reader itself cannot be counted, as the scanner reads ahead of the current line.
+func (p *Parser) scanLines(data []byte, atEOF bool) (int, []byte, error) {
+	advance, token, err := bufio.ScanLines(data, atEOF)
+	if token != nil {
+		p.lineOffset = p.consumed
+	}
+	p.consumed += int64(advance)
+	return advance, token, err
+}
+
+// maxLineLength returns the maximum length of a line, line ending excluded.
+func (p *Parser) maxLineLength() int {
+	if p.opts.MaxLineLength > 0 {
+		return p.opts.MaxLineLength
+	}
+	return defaultMaxLineLength
+}
+
+// Next parses the next command and returns the corresponding event.
+// At the end of the input, it validates the cue sheet, unless ParseOptions.SkipValidation is set,
+// and returns io.EOF if it is valid.
+// After an error, Next keeps returning the same error.
+func (p *Parser) Next() (Event, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	for p.scanner.Scan() {
+		line := strings.TrimSpace(p.scanner.Text())
+		p.lineNr++
+		if line == "" {
+			continue
+		}
+		event, err := p.parseLine(line)
+		if err != nil {
+			p.err = &ParseError{Line: p.lineNr, Text: line, ByteOffset: p.lineOffset, Err: err}
+			return nil, p.err
+		}
+		if event != nil {
+			return event, nil
+		}
+	}
+	if err := p.scanner.Err(); errors.Is(err, bufio.ErrTooLong) {
+		p.err = &ParseError{Line: p.lineNr + 1, ByteOffset: p.consumed, Err: fmt.Errorf("line longer than %d bytes: %w", p.maxLineLength(), err)}
+	} else if err != nil {
+		p.err = fmt.Errorf("error reading cue sheet: %w", err)
+	} else if p.opts.SkipValidation {
+		p.err = io.EOF
+	} else if err := p.validate(); err != nil {
+		p.err = fmt.Errorf("invalid cue sheet: %w", err)
+	} else {
+		p.err = io.EOF
+	}
+	return nil, p.err
+}
+
+// validate validates the parsed cue sheet, with the Red Book rules if ParseOptions.StrictRedBook is set.
+// With ParseOptions.CollectAllErrors, the default rules are all reported in a MultiError.
+func (p *Parser) validate() error {
+	if p.opts.CollectAllErrors {
+		if errs := p.c.validationErrors(); len(errs) > 0 {
+			return NewMultiError(errs...)
+		}
+	}
+	if p.opts.StrictRedBook {
+		return p.c.ValidateStrictWithOptions(RedBookOptions{ExtendedCapacity: p.opts.ExtendedDiscCapacity})
+	}
+	return p.c.validate()
+}
+
+// event returns the event describing the command that has just been parsed.
+func (p *Parser) event(command CommandDef, parameters []string) Event {
+	var (
+		c     = p.c
+		track = len(c.Tracks)
+	)
+	switch command {
+	case FileCommand:
+		file := c.Files[len(c.Files)-1]
+		return FileEvent{FileName: file.FileName, Format: file.Format}
+	case PerformerCommand:
+		if track == 0 {
+			return PerformerEvent{Performer: c.AlbumPerformer}
+		}
+		return PerformerEvent{Track: track, Performer: c.Tracks[track-1].Performer}
+	case TitleCommand:
+		if track == 0 {
+			return TitleEvent{Title: c.AlbumTitle}
+		}
+		return TitleEvent{Track: track, Title: c.Tracks[track-1].Title}
+	case CatalogCommand:
+		return CatalogEvent{Catalog: c.Catalog}
+	case TrackCommand:
+		return TrackEvent{Number: track, Type: c.Tracks[track-1].Type}
+	case ISRCCommand:
+		return ISRCEvent{Track: track, ISRC: c.Tracks[track-1].ISRC}
+	case IndexCommand:
+		event := IndexEvent{Track: track, Number: p.lastIndex}
+		switch t := c.Tracks[track-1]; p.lastIndex {
+		case 0:
+			event.Point = *t.Index00
+		case 1:
+			event.Point = t.Index01
+		default:
+			event.Point = t.Indices[p.lastIndex-2]
+		}
+		return event
+	default:
+		return RemEvent{Track: track, Key: parameters[0], Value: strings.Join(parameters[1:], " ")}
+	}
+}
@@ -0,0 +1,76 @@
+package cuesheetgo
+
+import (
+	"io"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParserEvents(t *testing.T) {
+	p := NewParser(open(t, "all.cue"))
+	c := CueSheet{Tracks: []Track{}}
+	for {
+		event, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		switch e := event.(type) {
+		case FileEvent:
+			c.Files = append(c.Files, FileEntry{FileName: e.FileName, Format: e.Format})
+		case PerformerEvent:
+			c.AlbumPerformer = e.Performer
+		case TitleEvent:
+			if e.Track == 0 {
+				c.AlbumTitle = e.Title
+			} else {
+				c.Tracks[e.Track-1].Title = e.Title
+			}
+		case TrackEvent:
+			c.Tracks = append(c.Tracks, Track{Number: e.Number, Type: e.Type})
+		case IndexEvent:
+			require.Equal(t, 1, e.Number)
+			c.Tracks[e.Track-1].Index01 = e.Point
+		default:
+			require.Failf(t, "unexpected event", "%#v", event)
+		}
+	}
+	require.Equal(t, allCueSheet, c)
+
+	_, err := p.Next()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestParserRemEvents(t *testing.T) {
+	p := NewParser(open(t, path.Join("rem", "comments.cue")))
+	var events []RemEvent
+	for {
+		event, err := p.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if rem, ok := event.(RemEvent); ok {
+			events = append(events, rem)
+		}
+	}
+	require.Equal(t, []RemEvent{
+		{Key: "COMMENT", Value: `"ExactAudioCopy v1.6"`},
+		{Key: "LABEL", Value: "Sample"},
+		{Track: 1, Key: "COMMENT", Value: `"Live recording"`},
+	}, events)
+}
+
+func TestParserError(t *testing.T) {
+	p := NewParser(open(t, path.Join("index", "without_track.cue")))
+	var err error
+	for err == nil {
+		_, err = p.Next()
+	}
+	require.ErrorContains(t, err, "INDEX: no current track")
+
+	_, again := p.Next()
+	require.Equal(t, err, again)
+}
@@ -0,0 +1,173 @@
+// Package cuesheetpb holds the Protocol Buffers messages of a cue sheet,
+// generated from cuesheet.proto, and their conversion from and to cuesheetgo types.
+package cuesheetpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative cuesheet.proto
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	cuesheetgo "github.com/lmvgo/cue"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// framesPerSecond is the number of frames in a second of a cue sheet index point.
+const framesPerSecond = 75
+
+// CueSheetToProto returns the message of the cue sheet c.
+func CueSheetToProto(c *cuesheetgo.CueSheet) *CueSheet {
+	p := &CueSheet{
+		AlbumPerformer:    c.AlbumPerformer,
+		AlbumTitle:        c.AlbumTitle,
+		Catalog:           c.Catalog,
+		Barcode:           c.Barcode,
+		AlbumGain:         c.AlbumGain,
+		AlbumPeak:         c.AlbumPeak,
+		MusicbrainzDiscid: c.MusicBrainzDiscID,
+		Date:              c.Date,
+		DiscNumber:        int32(c.DiscNumber),
+		TotalDiscs:        int32(c.TotalDiscs),
+		Comments:          slices.Clone(c.Comments),
+		Remarks:           slices.Clone(c.Remarks),
+	}
+	for _, file := range c.Files {
+		p.Files = append(p.Files, &FileEntry{FileName: file.FileName, Format: string(file.Format)})
+	}
+	for _, track := range c.Tracks {
+		p.Tracks = append(p.Tracks, trackToProto(track))
+	}
+	if c.DiscID != nil {
+		discID := *c.DiscID
+		p.DiscId = &discID
+	}
+	if c.TotalLength != nil {
+		p.TotalLength = indexPointToProto(*c.TotalLength)
+	}
+	return p
+}
+
+func trackToProto(t cuesheetgo.Track) *Track {
+	p := &Track{
+		Number:    int32(t.Number),
+		Type:      string(t.Type),
+		Title:     t.Title,
+		Performer: t.Performer,
+		Isrc:      t.ISRC,
+		Index01:   indexPointToProto(t.Index01),
+		TrackGain: t.TrackGain,
+		TrackPeak: t.TrackPeak,
+		File:      int32(t.File),
+		Comments:  slices.Clone(t.Comments),
+		Remarks:   slices.Clone(t.Remarks),
+	}
+	if t.Index00 != nil {
+		p.Index00 = indexPointToProto(*t.Index00)
+	}
+	for _, idx := range t.Indices {
+		p.Indices = append(p.Indices, indexPointToProto(idx))
+	}
+	return p
+}
+
+func indexPointToProto(idx cuesheetgo.IndexPoint) *IndexPoint {
+	return &IndexPoint{Timestamp: durationpb.New(idx.Timestamp), Frame: int32(idx.Frame)}
+}
+
+// ProtoToCueSheet returns the cue sheet of the message p.
+// It returns an error if an index point is not a whole number of seconds and frames.
+// The cue sheet is not validated.
+func ProtoToCueSheet(p *CueSheet) (*cuesheetgo.CueSheet, error) {
+	c := &cuesheetgo.CueSheet{
+		AlbumPerformer:    p.GetAlbumPerformer(),
+		AlbumTitle:        p.GetAlbumTitle(),
+		Catalog:           p.GetCatalog(),
+		Barcode:           p.GetBarcode(),
+		AlbumGain:         p.GetAlbumGain(),
+		AlbumPeak:         p.GetAlbumPeak(),
+		MusicBrainzDiscID: p.GetMusicbrainzDiscid(),
+		Date:              p.GetDate(),
+		DiscNumber:        int(p.GetDiscNumber()),
+		TotalDiscs:        int(p.GetTotalDiscs()),
+		Comments:          slices.Clone(p.GetComments()),
+		Remarks:           slices.Clone(p.GetRemarks()),
+	}
+	for _, file := range p.GetFiles() {
+		c.Files = append(c.Files, cuesheetgo.FileEntry{FileName: file.GetFileName(), Format: cuesheetgo.AudioFormat(file.GetFormat())})
+	}
+	for i, track := range p.GetTracks() {
+		t, err := trackFromProto(track)
+		if err != nil {
+			return nil, fmt.Errorf("track %d: %w", i+1, err)
+		}
+		c.Tracks = append(c.Tracks, t)
+	}
+	if p.DiscId != nil {
+		discID := p.GetDiscId()
+		c.DiscID = &discID
+	}
+	if p.GetTotalLength() != nil {
+		totalLength, err := indexPointFromProto(p.GetTotalLength())
+		if err != nil {
+			return nil, fmt.Errorf("total length: %w", err)
+		}
+		c.TotalLength = &totalLength
+	}
+	return c, nil
+}
+
+func trackFromProto(p *Track) (cuesheetgo.Track, error) {
+	t := cuesheetgo.Track{
+		Number:    int(p.GetNumber()),
+		Type:      cuesheetgo.TrackType(p.GetType()),
+		Title:     p.GetTitle(),
+		Performer: p.GetPerformer(),
+		ISRC:      p.GetIsrc(),
+		TrackGain: p.GetTrackGain(),
+		TrackPeak: p.GetTrackPeak(),
+		File:      int(p.GetFile()),
+		Comments:  slices.Clone(p.GetComments()),
+		Remarks:   slices.Clone(p.GetRemarks()),
+	}
+	if p.GetIndex00() != nil {
+		index00, err := indexPointFromProto(p.GetIndex00())
+		if err != nil {
+			return cuesheetgo.Track{}, fmt.Errorf("INDEX 00: %w", err)
+		}
+		t.Index00 = &index00
+	}
+	var err error
+	if t.Index01, err = indexPointFromProto(p.GetIndex01()); err != nil {
+		return cuesheetgo.Track{}, fmt.Errorf("INDEX 01: %w", err)
+	}
+	for i, idx := range p.GetIndices() {
+		index, err := indexPointFromProto(idx)
+		if err != nil {
+			return cuesheetgo.Track{}, fmt.Errorf("INDEX %02d: %w", i+2, err)
+		}
+		t.Indices = append(t.Indices, index)
+	}
+	return t, nil
+}
+
+// indexPointFromProto returns the index point of p, zero when p is nil.
+func indexPointFromProto(p *IndexPoint) (cuesheetgo.IndexPoint, error) {
+	if p == nil {
+		return cuesheetgo.IndexPoint{}, nil
+	}
+	var timestamp time.Duration
+	if p.GetTimestamp() != nil {
+		if err := p.GetTimestamp().CheckValid(); err != nil {
+			return cuesheetgo.IndexPoint{}, err
+		}
+		timestamp = p.GetTimestamp().AsDuration()
+	}
+	if timestamp < 0 || timestamp%time.Second != 0 {
+		return cuesheetgo.IndexPoint{}, fmt.Errorf("timestamp %s is not a whole number of seconds", timestamp)
+	}
+	if p.GetFrame() < 0 || p.GetFrame() >= framesPerSecond {
+		return cuesheetgo.IndexPoint{}, fmt.Errorf("frame %d out of range [0, %d)", p.GetFrame(), framesPerSecond)
+	}
+	return cuesheetgo.IndexPoint{Timestamp: timestamp, Frame: int(p.GetFrame())}, nil
+}
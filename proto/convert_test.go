@@ -0,0 +1,88 @@
+package cuesheetpb
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	cuesheetgo "github.com/lmvgo/cue"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const sampleCueSheet = `REM COMMENT "Ripped from the original CD"
+REM DATE 1999
+REM DISCID 9A0B3C0D
+REM EAN 4006381333931
+REM DISCNUMBER 1
+REM TOTALDISCS 2
+REM GENERATOR "Some Ripper"
+CATALOG 0123456789012
+PERFORMER "Sample Album Artist"
+TITLE "Sample Album"
+FILE "first.flac" WAVE
+  TRACK 01 AUDIO
+    TITLE "First Track"
+    ISRC USRC17607839
+    REM REPLAYGAIN_TRACK_GAIN -7.50 dB
+    REM LABEL Sample
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    PERFORMER "Guest Artist"
+    INDEX 00 03:58:12
+    INDEX 01 04:00:00
+    INDEX 02 05:10:74
+FILE "second.wav" WAVE
+  TRACK 03 MODE1/2352
+    INDEX 01 00:00:00
+`
+
+func TestCueSheetProtoRoundTrip(t *testing.T) {
+	c, err := cuesheetgo.Parse(strings.NewReader(sampleCueSheet))
+	require.NoError(t, err)
+
+	data, err := proto.Marshal(CueSheetToProto(c))
+	require.NoError(t, err)
+	var p CueSheet
+	require.NoError(t, proto.Unmarshal(data, &p))
+	require.Equal(t, uint32(0x9A0B3C0D), p.GetDiscId())
+	require.Len(t, p.GetFiles(), 2)
+	require.Equal(t, int32(1), p.GetTracks()[2].GetFile())
+
+	converted, err := ProtoToCueSheet(&p)
+	require.NoError(t, err)
+	require.Equal(t, c, converted)
+
+	c = &cuesheetgo.CueSheet{
+		Files:  []cuesheetgo.FileEntry{{FileName: "sample.flac", Format: cuesheetgo.AudioFormatWave}},
+		Tracks: []cuesheetgo.Track{{Number: 1, Type: cuesheetgo.TrackTypeAudio}},
+	}
+	converted, err = ProtoToCueSheet(CueSheetToProto(c))
+	require.NoError(t, err)
+	require.Equal(t, c, converted)
+	require.Nil(t, converted.DiscID)
+}
+
+func TestProtoToCueSheetInvalidIndexPoint(t *testing.T) {
+	tcs := []struct {
+		name        string
+		index       *IndexPoint
+		expectedErr string
+	}{
+		{name: "FrameOutOfRange", index: &IndexPoint{Frame: 75}, expectedErr: "track 1: INDEX 01: frame 75 out of range [0, 75)"},
+		{name: "NegativeFrame", index: &IndexPoint{Frame: -1}, expectedErr: "frame -1 out of range"},
+		{name: "FractionalSecond", index: &IndexPoint{Timestamp: durationpb.New(1500 * time.Millisecond)}, expectedErr: "timestamp 1.5s is not a whole number of seconds"},
+		{name: "NegativeTimestamp", index: &IndexPoint{Timestamp: durationpb.New(-time.Second)}, expectedErr: "timestamp -1s is not a whole number of seconds"},
+		{name: "InvalidDuration", index: &IndexPoint{Timestamp: &durationpb.Duration{Seconds: 1, Nanos: -1}}, expectedErr: "track 1: INDEX 01"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ProtoToCueSheet(&CueSheet{Tracks: []*Track{{Number: 1, Index01: tc.index}}})
+			require.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+
+	_, err := ProtoToCueSheet(&CueSheet{TotalLength: &IndexPoint{Frame: 80}})
+	require.ErrorContains(t, err, "total length: frame 80 out of range")
+}
@@ -0,0 +1,553 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: cuesheet.proto
+
+package cuesheetpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// IndexPoint is a position in an audio file, in seconds and frames of 1/75 second.
+type IndexPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *durationpb.Duration   `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Frame         int32                  `protobuf:"varint,2,opt,name=frame,proto3" json:"frame,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexPoint) Reset() {
+	*x = IndexPoint{}
+	mi := &file_cuesheet_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexPoint) ProtoMessage() {}
+
+func (x *IndexPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_cuesheet_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexPoint.ProtoReflect.Descriptor instead.
+func (*IndexPoint) Descriptor() ([]byte, []int) {
+	return file_cuesheet_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IndexPoint) GetTimestamp() *durationpb.Duration {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *IndexPoint) GetFrame() int32 {
+	if x != nil {
+		return x.Frame
+	}
+	return 0
+}
+
+// FileEntry is an audio file referenced by a FILE command.
+type FileEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileEntry) Reset() {
+	*x = FileEntry{}
+	mi := &file_cuesheet_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileEntry) ProtoMessage() {}
+
+func (x *FileEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_cuesheet_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileEntry.ProtoReflect.Descriptor instead.
+func (*FileEntry) Descriptor() ([]byte, []int) {
+	return file_cuesheet_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FileEntry) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *FileEntry) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+// Track is a TRACK command and the commands that follow it.
+type Track struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Number    int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Type      string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Title     string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Performer string                 `protobuf:"bytes,4,opt,name=performer,proto3" json:"performer,omitempty"`
+	Isrc      string                 `protobuf:"bytes,5,opt,name=isrc,proto3" json:"isrc,omitempty"`
+	// index00 is unset when the track has no pregap.
+	Index00   *IndexPoint   `protobuf:"bytes,6,opt,name=index00,proto3" json:"index00,omitempty"`
+	Index01   *IndexPoint   `protobuf:"bytes,7,opt,name=index01,proto3" json:"index01,omitempty"`
+	Indices   []*IndexPoint `protobuf:"bytes,8,rep,name=indices,proto3" json:"indices,omitempty"`
+	TrackGain float64       `protobuf:"fixed64,9,opt,name=track_gain,json=trackGain,proto3" json:"track_gain,omitempty"`
+	TrackPeak float64       `protobuf:"fixed64,10,opt,name=track_peak,json=trackPeak,proto3" json:"track_peak,omitempty"`
+	// file is the index in CueSheet.files of the file in which the track starts.
+	File          int32    `protobuf:"varint,11,opt,name=file,proto3" json:"file,omitempty"`
+	Comments      []string `protobuf:"bytes,12,rep,name=comments,proto3" json:"comments,omitempty"`
+	Remarks       []string `protobuf:"bytes,13,rep,name=remarks,proto3" json:"remarks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Track) Reset() {
+	*x = Track{}
+	mi := &file_cuesheet_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Track) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Track) ProtoMessage() {}
+
+func (x *Track) ProtoReflect() protoreflect.Message {
+	mi := &file_cuesheet_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Track.ProtoReflect.Descriptor instead.
+func (*Track) Descriptor() ([]byte, []int) {
+	return file_cuesheet_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Track) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Track) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Track) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Track) GetPerformer() string {
+	if x != nil {
+		return x.Performer
+	}
+	return ""
+}
+
+func (x *Track) GetIsrc() string {
+	if x != nil {
+		return x.Isrc
+	}
+	return ""
+}
+
+func (x *Track) GetIndex00() *IndexPoint {
+	if x != nil {
+		return x.Index00
+	}
+	return nil
+}
+
+func (x *Track) GetIndex01() *IndexPoint {
+	if x != nil {
+		return x.Index01
+	}
+	return nil
+}
+
+func (x *Track) GetIndices() []*IndexPoint {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+func (x *Track) GetTrackGain() float64 {
+	if x != nil {
+		return x.TrackGain
+	}
+	return 0
+}
+
+func (x *Track) GetTrackPeak() float64 {
+	if x != nil {
+		return x.TrackPeak
+	}
+	return 0
+}
+
+func (x *Track) GetFile() int32 {
+	if x != nil {
+		return x.File
+	}
+	return 0
+}
+
+func (x *Track) GetComments() []string {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *Track) GetRemarks() []string {
+	if x != nil {
+		return x.Remarks
+	}
+	return nil
+}
+
+// CueSheet is the content of a cue sheet file.
+type CueSheet struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	AlbumPerformer    string                 `protobuf:"bytes,1,opt,name=album_performer,json=albumPerformer,proto3" json:"album_performer,omitempty"`
+	AlbumTitle        string                 `protobuf:"bytes,2,opt,name=album_title,json=albumTitle,proto3" json:"album_title,omitempty"`
+	Files             []*FileEntry           `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	Tracks            []*Track               `protobuf:"bytes,4,rep,name=tracks,proto3" json:"tracks,omitempty"`
+	Catalog           string                 `protobuf:"bytes,5,opt,name=catalog,proto3" json:"catalog,omitempty"`
+	AlbumGain         float64                `protobuf:"fixed64,6,opt,name=album_gain,json=albumGain,proto3" json:"album_gain,omitempty"`
+	AlbumPeak         float64                `protobuf:"fixed64,7,opt,name=album_peak,json=albumPeak,proto3" json:"album_peak,omitempty"`
+	DiscId            *uint32                `protobuf:"varint,8,opt,name=disc_id,json=discId,proto3,oneof" json:"disc_id,omitempty"`
+	MusicbrainzDiscid string                 `protobuf:"bytes,9,opt,name=musicbrainz_discid,json=musicbrainzDiscid,proto3" json:"musicbrainz_discid,omitempty"`
+	Date              string                 `protobuf:"bytes,10,opt,name=date,proto3" json:"date,omitempty"`
+	DiscNumber        int32                  `protobuf:"varint,11,opt,name=disc_number,json=discNumber,proto3" json:"disc_number,omitempty"`
+	TotalDiscs        int32                  `protobuf:"varint,12,opt,name=total_discs,json=totalDiscs,proto3" json:"total_discs,omitempty"`
+	Comments          []string               `protobuf:"bytes,13,rep,name=comments,proto3" json:"comments,omitempty"`
+	Remarks           []string               `protobuf:"bytes,14,rep,name=remarks,proto3" json:"remarks,omitempty"`
+	// total_length is unset when the length of the audio file is not known.
+	TotalLength   *IndexPoint `protobuf:"bytes,15,opt,name=total_length,json=totalLength,proto3" json:"total_length,omitempty"`
+	Barcode       string      `protobuf:"bytes,16,opt,name=barcode,proto3" json:"barcode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CueSheet) Reset() {
+	*x = CueSheet{}
+	mi := &file_cuesheet_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CueSheet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CueSheet) ProtoMessage() {}
+
+func (x *CueSheet) ProtoReflect() protoreflect.Message {
+	mi := &file_cuesheet_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CueSheet.ProtoReflect.Descriptor instead.
+func (*CueSheet) Descriptor() ([]byte, []int) {
+	return file_cuesheet_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CueSheet) GetAlbumPerformer() string {
+	if x != nil {
+		return x.AlbumPerformer
+	}
+	return ""
+}
+
+func (x *CueSheet) GetAlbumTitle() string {
+	if x != nil {
+		return x.AlbumTitle
+	}
+	return ""
+}
+
+func (x *CueSheet) GetFiles() []*FileEntry {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *CueSheet) GetTracks() []*Track {
+	if x != nil {
+		return x.Tracks
+	}
+	return nil
+}
+
+func (x *CueSheet) GetCatalog() string {
+	if x != nil {
+		return x.Catalog
+	}
+	return ""
+}
+
+func (x *CueSheet) GetAlbumGain() float64 {
+	if x != nil {
+		return x.AlbumGain
+	}
+	return 0
+}
+
+func (x *CueSheet) GetAlbumPeak() float64 {
+	if x != nil {
+		return x.AlbumPeak
+	}
+	return 0
+}
+
+func (x *CueSheet) GetDiscId() uint32 {
+	if x != nil && x.DiscId != nil {
+		return *x.DiscId
+	}
+	return 0
+}
+
+func (x *CueSheet) GetMusicbrainzDiscid() string {
+	if x != nil {
+		return x.MusicbrainzDiscid
+	}
+	return ""
+}
+
+func (x *CueSheet) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *CueSheet) GetDiscNumber() int32 {
+	if x != nil {
+		return x.DiscNumber
+	}
+	return 0
+}
+
+func (x *CueSheet) GetTotalDiscs() int32 {
+	if x != nil {
+		return x.TotalDiscs
+	}
+	return 0
+}
+
+func (x *CueSheet) GetComments() []string {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *CueSheet) GetRemarks() []string {
+	if x != nil {
+		return x.Remarks
+	}
+	return nil
+}
+
+func (x *CueSheet) GetTotalLength() *IndexPoint {
+	if x != nil {
+		return x.TotalLength
+	}
+	return nil
+}
+
+func (x *CueSheet) GetBarcode() string {
+	if x != nil {
+		return x.Barcode
+	}
+	return ""
+}
+
+var File_cuesheet_proto protoreflect.FileDescriptor
+
+const file_cuesheet_proto_rawDesc = "" +
+	"\n" +
+	"\x0ecuesheet.proto\x12\bcuesheet\x1a\x1egoogle/protobuf/duration.proto\"[\n" +
+	"\n" +
+	"IndexPoint\x127\n" +
+	"\ttimestamp\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\ttimestamp\x12\x14\n" +
+	"\x05frame\x18\x02 \x01(\x05R\x05frame\"@\n" +
+	"\tFileEntry\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\x93\x03\n" +
+	"\x05Track\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x1c\n" +
+	"\tperformer\x18\x04 \x01(\tR\tperformer\x12\x12\n" +
+	"\x04isrc\x18\x05 \x01(\tR\x04isrc\x12.\n" +
+	"\aindex00\x18\x06 \x01(\v2\x14.cuesheet.IndexPointR\aindex00\x12.\n" +
+	"\aindex01\x18\a \x01(\v2\x14.cuesheet.IndexPointR\aindex01\x12.\n" +
+	"\aindices\x18\b \x03(\v2\x14.cuesheet.IndexPointR\aindices\x12\x1d\n" +
+	"\n" +
+	"track_gain\x18\t \x01(\x01R\ttrackGain\x12\x1d\n" +
+	"\n" +
+	"track_peak\x18\n" +
+	" \x01(\x01R\ttrackPeak\x12\x12\n" +
+	"\x04file\x18\v \x01(\x05R\x04file\x12\x1a\n" +
+	"\bcomments\x18\f \x03(\tR\bcomments\x12\x18\n" +
+	"\aremarks\x18\r \x03(\tR\aremarks\"\xb8\x04\n" +
+	"\bCueSheet\x12'\n" +
+	"\x0falbum_performer\x18\x01 \x01(\tR\x0ealbumPerformer\x12\x1f\n" +
+	"\valbum_title\x18\x02 \x01(\tR\n" +
+	"albumTitle\x12)\n" +
+	"\x05files\x18\x03 \x03(\v2\x13.cuesheet.FileEntryR\x05files\x12'\n" +
+	"\x06tracks\x18\x04 \x03(\v2\x0f.cuesheet.TrackR\x06tracks\x12\x18\n" +
+	"\acatalog\x18\x05 \x01(\tR\acatalog\x12\x1d\n" +
+	"\n" +
+	"album_gain\x18\x06 \x01(\x01R\talbumGain\x12\x1d\n" +
+	"\n" +
+	"album_peak\x18\a \x01(\x01R\talbumPeak\x12\x1c\n" +
+	"\adisc_id\x18\b \x01(\rH\x00R\x06discId\x88\x01\x01\x12-\n" +
+	"\x12musicbrainz_discid\x18\t \x01(\tR\x11musicbrainzDiscid\x12\x12\n" +
+	"\x04date\x18\n" +
+	" \x01(\tR\x04date\x12\x1f\n" +
+	"\vdisc_number\x18\v \x01(\x05R\n" +
+	"discNumber\x12\x1f\n" +
+	"\vtotal_discs\x18\f \x01(\x05R\n" +
+	"totalDiscs\x12\x1a\n" +
+	"\bcomments\x18\r \x03(\tR\bcomments\x12\x18\n" +
+	"\aremarks\x18\x0e \x03(\tR\aremarks\x127\n" +
+	"\ftotal_length\x18\x0f \x01(\v2\x14.cuesheet.IndexPointR\vtotalLength\x12\x18\n" +
+	"\abarcode\x18\x10 \x01(\tR\abarcodeB\n" +
+	"\n" +
+	"\b_disc_idB'Z%github.com/lmvgo/cue/proto;cuesheetpbb\x06proto3"
+
+var (
+	file_cuesheet_proto_rawDescOnce sync.Once
+	file_cuesheet_proto_rawDescData []byte
+)
+
+func file_cuesheet_proto_rawDescGZIP() []byte {
+	file_cuesheet_proto_rawDescOnce.Do(func() {
+		file_cuesheet_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_cuesheet_proto_rawDesc), len(file_cuesheet_proto_rawDesc)))
+	})
+	return file_cuesheet_proto_rawDescData
+}
+
+var file_cuesheet_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_cuesheet_proto_goTypes = []any{
+	(*IndexPoint)(nil),          // 0: cuesheet.IndexPoint
+	(*FileEntry)(nil),           // 1: cuesheet.FileEntry
+	(*Track)(nil),               // 2: cuesheet.Track
+	(*CueSheet)(nil),            // 3: cuesheet.CueSheet
+	(*durationpb.Duration)(nil), // 4: google.protobuf.Duration
+}
+var file_cuesheet_proto_depIdxs = []int32{
+	4, // 0: cuesheet.IndexPoint.timestamp:type_name -> google.protobuf.Duration
+	0, // 1: cuesheet.Track.index00:type_name -> cuesheet.IndexPoint
+	0, // 2: cuesheet.Track.index01:type_name -> cuesheet.IndexPoint
+	0, // 3: cuesheet.Track.indices:type_name -> cuesheet.IndexPoint
+	1, // 4: cuesheet.CueSheet.files:type_name -> cuesheet.FileEntry
+	2, // 5: cuesheet.CueSheet.tracks:type_name -> cuesheet.Track
+	0, // 6: cuesheet.CueSheet.total_length:type_name -> cuesheet.IndexPoint
+	7, // [7:7] is the sub-list for method output_type
+	7, // [7:7] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_cuesheet_proto_init() }
+func file_cuesheet_proto_init() {
+	if File_cuesheet_proto != nil {
+		return
+	}
+	file_cuesheet_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_cuesheet_proto_rawDesc), len(file_cuesheet_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_cuesheet_proto_goTypes,
+		DependencyIndexes: file_cuesheet_proto_depIdxs,
+		MessageInfos:      file_cuesheet_proto_msgTypes,
+	}.Build()
+	File_cuesheet_proto = out.File
+	file_cuesheet_proto_goTypes = nil
+	file_cuesheet_proto_depIdxs = nil
+}
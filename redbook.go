@@ -0,0 +1,64 @@
+package cuesheetgo
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// maxDiscLength is the longest playing time of a standard audio CD.
+	maxDiscLength = 74 * time.Minute
+	// maxExtendedDiscLength is the longest playing time of an extended capacity audio CD.
+	maxExtendedDiscLength = 80 * time.Minute
+)
+
+// RedBookOptions configures ValidateStrictWithOptions.
+type RedBookOptions struct {
+	// ExtendedCapacity allows discs of up to 80 minutes instead of the standard 74 minutes.
+	ExtendedCapacity bool
+}
+
+// ValidateStrict validates the cue sheet like the parser does, then checks the Red Book
+// audio CD rules that can be derived from it:
+//   - the first track starts after the 2 second lead-in, at 00:02:00 or later;
+//   - when the total length is known, the last track lasts at least 2 seconds;
+//   - the disc, up to its total length or else to the start of the last track, lasts at most 74 minutes.
+//
+// With several files, whose lengths are unknown, every file but the last is counted in the
+// length of the disc up to the start of its last track, and the total length is the length
+// of the last file.
+func (c *CueSheet) ValidateStrict() error {
+	return c.ValidateStrictWithOptions(RedBookOptions{})
+}
+
+// ValidateStrictWithOptions is like ValidateStrict but allows configuring the rules through opts.
+func (c *CueSheet) ValidateStrictWithOptions(opts RedBookOptions) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	leadIn := IndexPointFromFrames(leadInFrames)
+	if first := c.Tracks[0].Index01; first.LessThan(leadIn) {
+		return fmt.Errorf("first track starts at %s, before the end of the lead-in at %s", first, leadIn)
+	}
+	end := c.Tracks[len(c.Tracks)-1].Index01
+	if c.TotalLength != nil {
+		if frames := c.TotalLength.AbsoluteFrames() - end.AbsoluteFrames(); frames < leadInFrames {
+			return fmt.Errorf("last track lasts %d frames, less than %d", frames, leadInFrames)
+		}
+		end = *c.TotalLength
+	}
+	frames := end.AbsoluteFrames()
+	for i := 1; i < len(c.Tracks); i++ {
+		if c.Tracks[i].File != c.Tracks[i-1].File {
+			frames += c.Tracks[i-1].Index01.AbsoluteFrames()
+		}
+	}
+	maxLength := maxDiscLength
+	if opts.ExtendedCapacity {
+		maxLength = maxExtendedDiscLength
+	}
+	if length := FrameToDuration(frames); length > maxLength {
+		return fmt.Errorf("disc length %s exceeds %s", IndexPointFromFrames(frames), maxLength)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package cuesheetgo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateStrict(t *testing.T) {
+	newCueSheet := func() *CueSheet {
+		return &CueSheet{
+			Files: []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+			Tracks: []Track{
+				{Number: 1, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Second}},
+				{Number: 2, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: time.Minute}},
+			},
+			TotalLength: &IndexPoint{Timestamp: 2 * time.Minute},
+		}
+	}
+	require.NoError(t, newCueSheet().ValidateStrict())
+
+	tcs := []struct {
+		name        string
+		modify      func(c *CueSheet)
+		expectedErr string
+	}{
+		{
+			name:        "Invalid",
+			modify:      func(c *CueSheet) { c.Files = nil },
+			expectedErr: "missing file name",
+		},
+		{
+			name:        "LeadIn",
+			modify:      func(c *CueSheet) { c.Tracks[0].Index01 = IndexPoint{Timestamp: time.Second, Frame: 74} },
+			expectedErr: "first track starts at 00:01:74, before the end of the lead-in at 00:02:00",
+		},
+		{
+			name:        "ShortLastTrack",
+			modify:      func(c *CueSheet) { c.TotalLength = &IndexPoint{Timestamp: time.Minute + time.Second, Frame: 74} },
+			expectedErr: "last track lasts 149 frames, less than 150",
+		},
+		{
+			name:        "TotalLength",
+			modify:      func(c *CueSheet) { c.TotalLength = &IndexPoint{Timestamp: 74 * time.Minute, Frame: 1} },
+			expectedErr: "disc length 74:00:01 exceeds 1h14m0s",
+		},
+		{
+			name: "LastTrack",
+			modify: func(c *CueSheet) {
+				c.TotalLength = nil
+				c.Tracks[1].Index01 = IndexPoint{Timestamp: 75 * time.Minute}
+			},
+			expectedErr: "disc length 75:00:00 exceeds 1h14m0s",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newCueSheet()
+			tc.modify(c)
+			require.EqualError(t, c.ValidateStrict(), tc.expectedErr)
+		})
+	}
+}
+
+func TestValidateStrictExtendedCapacity(t *testing.T) {
+	c := &CueSheet{
+		Files:       []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+		Tracks:      []Track{{Number: 1, Type: TrackTypeAudio, Index01: IndexPoint{Timestamp: 2 * time.Second}}},
+		TotalLength: &IndexPoint{Timestamp: 80 * time.Minute},
+	}
+	require.EqualError(t, c.ValidateStrict(), "disc length 80:00:00 exceeds 1h14m0s")
+	require.NoError(t, c.ValidateStrictWithOptions(RedBookOptions{ExtendedCapacity: true}))
+
+	c.TotalLength = &IndexPoint{Timestamp: 80 * time.Minute, Frame: 1}
+	require.EqualError(t, c.ValidateStrictWithOptions(RedBookOptions{ExtendedCapacity: true}), "disc length 80:00:01 exceeds 1h20m0s")
+}
+
+func TestValidateStrictMultipleFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	c.Tracks[0].Index01 = IndexPoint{Timestamp: 2 * time.Second}
+	c.Tracks[1].Index01 = IndexPoint{Timestamp: 37 * time.Minute}
+	c.TotalLength = &IndexPoint{Timestamp: 37 * time.Minute}
+	require.NoError(t, c.ValidateStrict())
+
+	// The first file lasts at least until its last track starts, at 37:00:00.
+	c.TotalLength = &IndexPoint{Timestamp: 37 * time.Minute, Frame: 1}
+	require.EqualError(t, c.ValidateStrict(), "disc length 74:00:01 exceeds 1h14m0s")
+
+	// The first track of the second file starts at 00:00:00, after the lead-in of the disc.
+	c.TotalLength = nil
+	require.NoError(t, c.ValidateStrict())
+}
+
+func TestParseStrictRedBook(t *testing.T) {
+	input := "FILE sample.flac WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:00\n"
+	_, err := Parse(strings.NewReader(input))
+	require.NoError(t, err)
+
+	_, err = ParseWithOptions(strings.NewReader(input), ParseOptions{StrictRedBook: true})
+	require.ErrorContains(t, err, "invalid cue sheet: first track starts at 00:00:00")
+
+	input = "REM TOTALLENGTH 78:00:00\nFILE sample.flac WAVE\nTRACK 01 AUDIO\nINDEX 01 00:02:00\n"
+	_, err = ParseWithOptions(strings.NewReader(input), ParseOptions{StrictRedBook: true})
+	require.ErrorContains(t, err, "invalid cue sheet: disc length 78:00:00 exceeds 1h14m0s")
+	_, err = ParseWithOptions(strings.NewReader(input), ParseOptions{StrictRedBook: true, ExtendedDiscCapacity: true})
+	require.NoError(t, err)
+}
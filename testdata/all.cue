@@ -1,6 +1,9 @@
 FILE "sample.flac" WAVE
 PERFORMER "Sample Album Artist"
+TITLE "Sample Album"
 TRACK 01 AUDIO
+    TITLE "First Track"
     INDEX 01 00:01:00
 TRACK 02 AUDIO
+    TITLE "Second Track"
     INDEX 01 01:00:00
@@ -0,0 +1,4 @@
+CATALOG 4006381333931
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
@@ -0,0 +1,4 @@
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    ISRC ABCDE1234567 EXTRA_PARAM
+    INDEX 01 00:00:00
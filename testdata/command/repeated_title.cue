@@ -0,0 +1,5 @@
+FILE "sample.flac" WAVE
+TITLE "Sample Album"
+TITLE "Other Album"
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
@@ -0,0 +1,8 @@
+CATALOG 4006381333931
+PERFORMER "Sample Album Artist"
+TITLE "Sample Album"
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    TITLE "First Track"
+    PERFORMER "First Artist"
+    INDEX 01 00:00:00
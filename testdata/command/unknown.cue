@@ -0,0 +1,6 @@
+FLAGS DCP
+FILE "sample.flac" WAVE
+EXTENSION foo bar
+TRACK 01 AUDIO
+    PREGAP 00:02:00
+    INDEX 01 00:00:00
@@ -0,0 +1,8 @@
+PERFORMER "Sample Album Artist"
+TITLE "Sample Album"
+FILE "disc1.wav" WAVE
+  TRACK 01 AUDIO
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    INDEX 00 00:50:00
+    INDEX 01 01:00:00
@@ -0,0 +1,6 @@
+PERFORMER "Other Artist"
+FILE "disc2.wav" WAVE
+  TRACK 01 AUDIO
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    INDEX 01 02:00:00
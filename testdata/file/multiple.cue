@@ -0,0 +1,8 @@
+FILE "first.wav" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
+TRACK 02 AUDIO
+    INDEX 01 03:00:00
+FILE "second.wav" WAVE
+TRACK 03 AUDIO
+    INDEX 01 00:00:00
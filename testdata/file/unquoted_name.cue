@@ -0,0 +1,3 @@
+FILE a b c WAVE
+TRACK 01 AUDIO
+  INDEX 01 00:00:00
@@ -0,0 +1,3 @@
+FILE "sample.flac" FLAC
+TRACK 01 AUDIO
+  INDEX 01 00:00:00
@@ -0,0 +1,3 @@
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:99
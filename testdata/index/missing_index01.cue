@@ -0,0 +1,5 @@
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
+TRACK 02 AUDIO
+    INDEX 00 01:00:00
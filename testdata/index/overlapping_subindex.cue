@@ -0,0 +1,4 @@
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:10:00
+    INDEX 02 00:05:00
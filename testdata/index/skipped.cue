@@ -0,0 +1,4 @@
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
+    INDEX 03 00:30:00
@@ -1,2 +1,2 @@
 FILE "sample.flac" WAVE
-FILE "sample.flac" WAVE
+INDEX 01 00:00:00
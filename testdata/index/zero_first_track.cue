@@ -0,0 +1,7 @@
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 00 00:00:00
+    INDEX 01 00:00:00
+TRACK 02 AUDIO
+    INDEX 00 00:58:00
+    INDEX 01 01:00:00
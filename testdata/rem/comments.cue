@@ -0,0 +1,6 @@
+REM COMMENT "ExactAudioCopy v1.6"
+REM LABEL Sample
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    REM COMMENT "Live recording"
+    INDEX 01 00:00:00
@@ -0,0 +1,5 @@
+REM DISCNUMBER 1
+REM TOTALDISCS 2
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
@@ -0,0 +1,5 @@
+REM DISCNUMBER 3
+REM TOTALDISCS 2
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
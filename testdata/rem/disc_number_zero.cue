@@ -0,0 +1,4 @@
+REM DISCNUMBER 0
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
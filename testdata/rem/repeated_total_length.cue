@@ -0,0 +1,5 @@
+REM TOTALLENGTH 02:00:00
+REM TOTALLENGTH 03:00:00
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
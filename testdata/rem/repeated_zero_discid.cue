@@ -0,0 +1,5 @@
+REM DISCID 00000000
+REM DISCID 00000000
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
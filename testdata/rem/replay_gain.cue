@@ -0,0 +1,11 @@
+REM REPLAYGAIN_ALBUM_GAIN -7.89 dB
+REM REPLAYGAIN_ALBUM_PEAK 0.988831
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    REM REPLAYGAIN_TRACK_GAIN -6.50 dB
+    REM REPLAYGAIN_TRACK_PEAK 0.950000
+    INDEX 01 00:01:00
+TRACK 02 AUDIO
+    REM REPLAYGAIN_TRACK_GAIN -8.12 dB
+    REM REPLAYGAIN_TRACK_PEAK 0.988831
+    INDEX 01 01:00:00
@@ -0,0 +1,4 @@
+REM DISCID 860B64
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
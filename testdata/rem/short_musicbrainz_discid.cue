@@ -0,0 +1,4 @@
+REM MUSICBRAINZ_DISCID 49HHV7Eb8UKF3aQiNmu1GR8vKTY
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
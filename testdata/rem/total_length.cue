@@ -0,0 +1,10 @@
+REM TOTALLENGTH 02:00:30
+PERFORMER "Sample Album Artist"
+TITLE "Sample Album"
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    TITLE "First Track"
+    INDEX 01 00:01:00
+TRACK 02 AUDIO
+    TITLE "Second Track"
+    INDEX 01 01:00:00
@@ -0,0 +1,7 @@
+REM GENERATOR "Some Ripper"
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    REM COMPOSER "Some Composer"
+    REM REPLAYGAIN_TRACK_GAIN -7.89 dB
+    REM DATE 1989
+    INDEX 01 00:00:00
@@ -0,0 +1,4 @@
+REM REPLAYGAIN_TRACK_GAIN -6.50 dB
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
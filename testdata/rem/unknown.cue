@@ -0,0 +1,4 @@
+REM GENERATOR Some Ripper
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+INDEX 01 00:00:00
@@ -0,0 +1,3 @@
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
+FILE "sample.flac" WAVE
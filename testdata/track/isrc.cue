@@ -0,0 +1,4 @@
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+    ISRC USRC17607839
+    INDEX 01 00:00:00
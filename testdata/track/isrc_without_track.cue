@@ -0,0 +1,4 @@
+FILE "sample.flac" WAVE
+ISRC USRC17607839
+TRACK 01 AUDIO
+    INDEX 01 00:00:00
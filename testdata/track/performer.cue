@@ -0,0 +1,7 @@
+FILE "sample.flac" WAVE
+PERFORMER "Various Artists"
+TRACK 01 AUDIO
+    PERFORMER "First Artist"
+    INDEX 01 00:00:00
+TRACK 02 AUDIO
+    INDEX 01 01:00:00
@@ -0,0 +1,6 @@
+FILE "sample.flac" WAVE
+PERFORMER "Various Artists"
+TRACK 01 AUDIO
+    PERFORMER "First Artist"
+    PERFORMER "Other Artist"
+    INDEX 01 00:00:00
@@ -0,0 +1,2 @@
+FILE "sample.flac" WAVE
+TRACK 01 VIDEO
@@ -0,0 +1,284 @@
+package cuesheetgo
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInsufficientTracks is returned when a cue sheet has too few tracks to compute any track duration.
+	ErrInsufficientTracks = errors.New("insufficient tracks")
+	// ErrNoGap is returned when a track has no pregap.
+	ErrNoGap = errors.New("no gap")
+)
+
+// Track returns the track with the 1-based number n.
+// It returns false if n is out of range.
+func (c *CueSheet) Track(n int) (*Track, bool) {
+	if n < 1 || n > len(c.Tracks) {
+		return nil, false
+	}
+	return &c.Tracks[n-1], true
+}
+
+// TrackCount returns the number of tracks in the cue sheet.
+func (c *CueSheet) TrackCount() int {
+	return len(c.Tracks)
+}
+
+// AudioTracks returns pointers to the AUDIO tracks of the cue sheet.
+func (c *CueSheet) AudioTracks() []*Track {
+	return c.filterTracks(func(t *Track) bool { return t.Type == TrackTypeAudio })
+}
+
+// DataTracks returns pointers to the tracks of the cue sheet that are not AUDIO tracks.
+func (c *CueSheet) DataTracks() []*Track {
+	return c.filterTracks(func(t *Track) bool { return t.Type != TrackTypeAudio })
+}
+
+// GaplessTracks returns pointers to the tracks without pregap.
+func (c *CueSheet) GaplessTracks() []*Track {
+	return c.filterTracks((*Track).IsGapless)
+}
+
+// TracksWithGap returns pointers to the tracks with a pregap.
+func (c *CueSheet) TracksWithGap() []*Track {
+	return c.filterTracks((*Track).HasPreGap)
+}
+
+func (c *CueSheet) filterTracks(keep func(*Track) bool) []*Track {
+	var tracks []*Track
+	for i := range c.Tracks {
+		if keep(&c.Tracks[i]) {
+			tracks = append(tracks, &c.Tracks[i])
+		}
+	}
+	return tracks
+}
+
+// String returns a one-line summary of the track, such as "Track 01 (AUDIO) - Title [01:23:45]".
+// The title and the INDEX 01 position are omitted when empty.
+func (t *Track) String() string {
+	s := fmt.Sprintf("Track %02d (%s)", t.Number, t.Type)
+	if t.Title != "" {
+		s += " - " + t.Title
+	}
+	if !t.Index01.IsZero() {
+		s += " [" + t.Index01.String() + "]"
+	}
+	return s
+}
+
+// HasPreGap reports whether the track has a pregap, i.e. an INDEX 00.
+func (t *Track) HasPreGap() bool {
+	return t.Index00 != nil
+}
+
+// IsGapless reports whether the track has no pregap.
+func (t *Track) IsGapless() bool {
+	return t.Index00 == nil
+}
+
+// GapDuration returns the length of the pregap of the track with the 1-based number n,
+// from its INDEX 00 to its INDEX 01. It returns ErrTrackNotFound if n is out of range
+// and ErrNoGap if the track has no INDEX 00.
+func (c *CueSheet) GapDuration(n int) (time.Duration, error) {
+	track, ok := c.Track(n)
+	if !ok {
+		return 0, fmt.Errorf("%w: %d", ErrTrackNotFound, n)
+	}
+	if track.Index00 == nil {
+		return 0, fmt.Errorf("%w: track %d", ErrNoGap, n)
+	}
+	return FrameToDuration(track.Index01.AbsoluteFrames() - track.Index00.AbsoluteFrames()), nil
+}
+
+// TrackTitles returns the title of every track in order, empty for untitled tracks.
+func (c *CueSheet) TrackTitles() []string {
+	titles := make([]string, len(c.Tracks))
+	for i, track := range c.Tracks {
+		titles[i] = track.Title
+	}
+	return titles
+}
+
+// HasTrack reports whether a track has the given title, ignoring case.
+func (c *CueSheet) HasTrack(title string) bool {
+	_, _, ok := c.FindTrackByTitle(title)
+	return ok
+}
+
+// FindTrackByTitle returns the first track with the given title, ignoring case,
+// and its zero-based index in Tracks. An empty title matches no track.
+// It returns nil, -1, false when no track matches.
+func (c *CueSheet) FindTrackByTitle(title string) (*Track, int, bool) {
+	i := slices.IndexFunc(c.Tracks, func(t Track) bool { return titleMatches(t, title) })
+	if i == -1 {
+		return nil, -1, false
+	}
+	return &c.Tracks[i], i, true
+}
+
+// FindTracksByTitle returns all the tracks with the given title, ignoring case.
+// An empty title matches no track.
+func (c *CueSheet) FindTracksByTitle(title string) []*Track {
+	return c.filterTracks(func(t *Track) bool { return titleMatches(*t, title) })
+}
+
+// FindTrackByISRC returns the track with the given ISRC, ignoring case, and its zero-based
+// index in Tracks. Tracks without ISRC are skipped.
+// It returns nil, -1, false when no track matches.
+func (c *CueSheet) FindTrackByISRC(isrc string) (*Track, int, bool) {
+	i := slices.IndexFunc(c.Tracks, func(t Track) bool { return t.ISRC != "" && strings.EqualFold(t.ISRC, isrc) })
+	if i == -1 {
+		return nil, -1, false
+	}
+	return &c.Tracks[i], i, true
+}
+
+// ContainsISRC reports whether a track has the given ISRC, ignoring case.
+func (c *CueSheet) ContainsISRC(isrc string) bool {
+	_, _, ok := c.FindTrackByISRC(isrc)
+	return ok
+}
+
+func titleMatches(t Track, title string) bool {
+	return title != "" && strings.EqualFold(t.Title, title)
+}
+
+// TrackDurations returns the duration of every track but the last one, from its INDEX 01
+// to the INDEX 01 of the next track. The last track has no known end, nor has the last track
+// of every file since index points restart in the next file: their duration is 0.
+func (c *CueSheet) TrackDurations() []time.Duration {
+	if len(c.Tracks) < 2 {
+		return nil
+	}
+	durations := make([]time.Duration, len(c.Tracks)-1)
+	for i := range durations {
+		durations[i], _ = c.trackDuration(i)
+	}
+	return durations
+}
+
+// trackDuration returns the duration of the track at index i of Tracks.
+// It returns false when the end of the track is unknown, as it is not followed
+// by a track in the same file.
+func (c *CueSheet) trackDuration(i int) (time.Duration, bool) {
+	if i+1 >= len(c.Tracks) || c.Tracks[i+1].File != c.Tracks[i].File {
+		return 0, false
+	}
+	return FrameToDuration(c.Tracks[i+1].Index01.AbsoluteFrames() - c.Tracks[i].Index01.AbsoluteFrames()), true
+}
+
+// TotalFrames returns the sum of the distinct INDEX 01 positions of the tracks, in frames.
+// It only depends on the index points, since the length of the last track is not known.
+// Positions in different files are distinct.
+func (c *CueSheet) TotalFrames() int {
+	type position struct{ file, frames int }
+	seen := make(map[position]bool, len(c.Tracks))
+	total := 0
+	for _, track := range c.Tracks {
+		pos := position{file: track.File, frames: track.Index01.AbsoluteFrames()}
+		if !seen[pos] {
+			seen[pos] = true
+			total += pos.frames
+		}
+	}
+	return total
+}
+
+// LongestTrack returns the first track with the longest known duration, see TrackDurations.
+// It returns ErrInsufficientTracks when no track has a known duration.
+func (c *CueSheet) LongestTrack() (*Track, time.Duration, error) {
+	return c.trackByDuration(func(a, b time.Duration) bool { return a > b })
+}
+
+// ShortestTrack returns the first track with the shortest known duration, see TrackDurations.
+// It returns ErrInsufficientTracks when no track has a known duration.
+func (c *CueSheet) ShortestTrack() (*Track, time.Duration, error) {
+	return c.trackByDuration(func(a, b time.Duration) bool { return a < b })
+}
+
+// trackByDuration returns the first track whose known duration is better than all the others.
+func (c *CueSheet) trackByDuration(better func(a, b time.Duration) bool) (*Track, time.Duration, error) {
+	best := -1
+	var bestDuration time.Duration
+	for i := range c.Tracks {
+		d, ok := c.trackDuration(i)
+		if ok && (best == -1 || better(d, bestDuration)) {
+			best, bestDuration = i, d
+		}
+	}
+	if best == -1 {
+		return nil, 0, ErrInsufficientTracks
+	}
+	return &c.Tracks[best], bestDuration, nil
+}
+
+// AverageTrackDuration returns the mean duration of the tracks with a known duration, see TrackDurations.
+// It returns ErrInsufficientTracks when no track has a known duration.
+func (c *CueSheet) AverageTrackDuration() (time.Duration, error) {
+	var (
+		total time.Duration
+		count int
+	)
+	for i := range c.Tracks {
+		if d, ok := c.trackDuration(i); ok {
+			total += d
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, ErrInsufficientTracks
+	}
+	return total / time.Duration(count), nil
+}
+
+// TrackList is the list of the tracks of a cue sheet, in order.
+type TrackList []Track
+
+// ByTitle returns the first track with the given title, ignoring case, or nil if none matches.
+// An empty title matches no track.
+func (l TrackList) ByTitle(title string) *Track {
+	i := slices.IndexFunc(l, func(t Track) bool { return titleMatches(t, title) })
+	if i == -1 {
+		return nil
+	}
+	return &l[i]
+}
+
+// ByNumber returns the track numbered n, or nil if there is none.
+func (l TrackList) ByNumber(n int) *Track {
+	i := slices.IndexFunc(l, func(t Track) bool { return t.Number == n })
+	if i == -1 {
+		return nil
+	}
+	return &l[i]
+}
+
+// Audio returns a new list with the AUDIO tracks of l.
+func (l TrackList) Audio() TrackList {
+	var audio TrackList
+	for _, track := range l {
+		if track.Type == TrackTypeAudio {
+			audio = append(audio, track)
+		}
+	}
+	return audio
+}
+
+// Duration returns the time from the INDEX 01 of the first track to the INDEX 01 of the last one,
+// zero for less than 2 tracks. The last track has no known end, so its duration is not included,
+// nor is the duration of the last track of every file since index points restart in the next file.
+func (l TrackList) Duration() time.Duration {
+	var frames int
+	for i := 1; i < len(l); i++ {
+		if l[i].File == l[i-1].File {
+			frames += l[i].Index01.AbsoluteFrames() - l[i-1].Index01.AbsoluteFrames()
+		}
+	}
+	return FrameToDuration(frames)
+}
@@ -0,0 +1,314 @@
+package cuesheetgo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrack(t *testing.T) {
+	c := allCueSheet.Clone()
+	require.Equal(t, 2, c.TrackCount())
+
+	for _, n := range []int{0, 3} {
+		track, ok := c.Track(n)
+		require.False(t, ok, "track %d", n)
+		require.Nil(t, track)
+	}
+	for _, n := range []int{1, 2} {
+		track, ok := c.Track(n)
+		require.True(t, ok, "track %d", n)
+		require.Same(t, &c.Tracks[n-1], track)
+		require.Equal(t, n, track.Number)
+	}
+}
+
+func TestTrackString(t *testing.T) {
+	track := &Track{Number: 1, Type: TrackTypeAudio, Title: "Title", Index01: IndexPoint{Timestamp: 83 * time.Minute, Frame: 45}}
+	require.Equal(t, "Track 01 (AUDIO) - Title [83:00:45]", track.String())
+
+	track.Title = ""
+	require.Equal(t, "Track 01 (AUDIO) [83:00:45]", track.String())
+
+	track.Index01 = IndexPoint{}
+	track.Number = 12
+	require.Equal(t, "Track 12 (AUDIO)", fmt.Sprint(track))
+}
+
+func TestTrackIndexPoints(t *testing.T) {
+	track := Track{
+		Index01: IndexPoint{Timestamp: time.Minute},
+		Indices: []IndexPoint{{Timestamp: 2 * time.Minute}, {Timestamp: 3 * time.Minute}},
+	}
+	require.Equal(t, []IndexPoint{
+		{Timestamp: time.Minute},
+		{Timestamp: 2 * time.Minute},
+		{Timestamp: 3 * time.Minute},
+	}, track.IndexPoints())
+
+	track.Index00 = &IndexPoint{Timestamp: 50 * time.Second}
+	require.Equal(t, []IndexPoint{
+		{Timestamp: 50 * time.Second},
+		{Timestamp: time.Minute},
+		{Timestamp: 2 * time.Minute},
+		{Timestamp: 3 * time.Minute},
+	}, track.IndexPoints())
+
+	require.Equal(t, []IndexPoint{{}}, (&Track{}).IndexPoints())
+}
+
+func TestGaplessTracks(t *testing.T) {
+	c := multipleIndicesCueSheet.Clone()
+	require.True(t, c.Tracks[0].IsGapless())
+	require.False(t, c.Tracks[0].HasPreGap())
+	require.False(t, c.Tracks[1].IsGapless())
+	require.True(t, c.Tracks[1].HasPreGap())
+
+	require.Equal(t, []*Track{&c.Tracks[0]}, c.GaplessTracks())
+	require.Equal(t, []*Track{&c.Tracks[1]}, c.TracksWithGap())
+}
+
+func TestAudioAndDataTracks(t *testing.T) {
+	c := &CueSheet{
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeMode12352},
+			{Number: 2, Type: TrackTypeAudio},
+			{Number: 3, Type: TrackTypeAudio},
+			{Number: 4, Type: TrackTypeCDG},
+		},
+	}
+	require.Equal(t, []*Track{&c.Tracks[1], &c.Tracks[2]}, c.AudioTracks())
+	require.Equal(t, []*Track{&c.Tracks[0], &c.Tracks[3]}, c.DataTracks())
+	require.Len(t, c.Tracks, 4)
+
+	c.Tracks = c.Tracks[1:3]
+	require.Empty(t, c.DataTracks())
+}
+
+func TestGapDuration(t *testing.T) {
+	c := multipleIndicesCueSheet.Clone()
+	c.Tracks[1].Index00.Frame = 15
+	d, err := c.GapDuration(2)
+	require.NoError(t, err)
+	require.Equal(t, time.Second+FrameToDuration(60), d)
+
+	d, err = c.GapDuration(1)
+	require.ErrorIs(t, err, ErrNoGap)
+	require.Zero(t, d)
+
+	for _, n := range []int{0, 3} {
+		_, err = c.GapDuration(n)
+		require.ErrorIs(t, err, ErrTrackNotFound, "track %d", n)
+	}
+}
+
+func TestTrackTitles(t *testing.T) {
+	c := &CueSheet{
+		Tracks: []Track{
+			{Number: 1, Title: "Intro"},
+			{Number: 2},
+			{Number: 3, Title: "Outro"},
+		},
+	}
+	require.Equal(t, []string{"Intro", "", "Outro"}, c.TrackTitles())
+	require.True(t, c.HasTrack("Intro"))
+	require.True(t, c.HasTrack("OUTRO"))
+	require.False(t, c.HasTrack("Interlude"))
+
+	require.Empty(t, (&CueSheet{}).TrackTitles())
+}
+
+func TestFindTrackByTitle(t *testing.T) {
+	c := &CueSheet{
+		Tracks: []Track{
+			{Number: 1, Title: "Intro"},
+			{Number: 2},
+			{Number: 3, Title: "Theme"},
+			{Number: 4, Title: "THEME"},
+		},
+	}
+
+	track, i, ok := c.FindTrackByTitle("intro")
+	require.True(t, ok)
+	require.Equal(t, 0, i)
+	require.Same(t, &c.Tracks[0], track)
+
+	track, i, ok = c.FindTrackByTitle("Theme")
+	require.True(t, ok)
+	require.Equal(t, 2, i)
+	require.Same(t, &c.Tracks[2], track)
+	require.Equal(t, []*Track{&c.Tracks[2], &c.Tracks[3]}, c.FindTracksByTitle("theme"))
+
+	for _, title := range []string{"Outro", ""} {
+		track, i, ok = c.FindTrackByTitle(title)
+		require.False(t, ok)
+		require.Equal(t, -1, i)
+		require.Nil(t, track)
+		require.Empty(t, c.FindTracksByTitle(title))
+		require.False(t, c.HasTrack(title))
+	}
+}
+
+func TestFindTrackByISRC(t *testing.T) {
+	c := &CueSheet{
+		Tracks: []Track{
+			{Number: 1, ISRC: "USRC17607839"},
+			{Number: 2},
+			{Number: 3, ISRC: "GBAYE0601498"},
+		},
+	}
+
+	track, i, ok := c.FindTrackByISRC("gbaye0601498")
+	require.True(t, ok)
+	require.Equal(t, 2, i)
+	require.Same(t, &c.Tracks[2], track)
+
+	require.True(t, c.ContainsISRC("USRC17607839"))
+	require.True(t, c.ContainsISRC("usrc17607839"))
+
+	for _, isrc := range []string{"FR6V81234567", ""} {
+		track, i, ok = c.FindTrackByISRC(isrc)
+		require.False(t, ok)
+		require.Equal(t, -1, i)
+		require.Nil(t, track)
+		require.False(t, c.ContainsISRC(isrc))
+	}
+}
+
+func TestTrackList(t *testing.T) {
+	c := &CueSheet{
+		Tracks: []Track{
+			{Number: 1, Type: TrackTypeAudio, Title: "Intro"},
+			{Number: 2, Type: TrackTypeMode12352, Index01: IndexPoint{Timestamp: time.Minute}},
+			{Number: 3, Type: TrackTypeAudio, Title: "Theme", Index01: IndexPoint{Timestamp: 3 * time.Minute, Frame: 15}},
+		},
+	}
+
+	require.Same(t, &c.Tracks[2], c.Tracks.ByTitle("THEME"))
+	require.Nil(t, c.Tracks.ByTitle("Outro"))
+	require.Nil(t, c.Tracks.ByTitle(""))
+
+	require.Same(t, &c.Tracks[1], c.Tracks.ByNumber(2))
+	require.Nil(t, c.Tracks.ByNumber(4))
+
+	audio := c.Tracks.Audio()
+	require.Equal(t, TrackList{c.Tracks[0], c.Tracks[2]}, audio)
+	require.Equal(t, 3*time.Minute+200*time.Millisecond, audio.Duration())
+	require.Equal(t, time.Minute, c.Tracks[:2].Duration())
+	require.Zero(t, c.Tracks[:1].Duration())
+	require.Empty(t, TrackList{}.Audio())
+}
+
+func TestLongestAndShortestTrack(t *testing.T) {
+	newCueSheet := func(starts ...time.Duration) *CueSheet {
+		c := &CueSheet{}
+		for i, start := range starts {
+			c.Tracks = append(c.Tracks, Track{Number: i + 1, Index01: IndexPoint{Timestamp: start}})
+		}
+		return c
+	}
+
+	t.Run("OneTrack", func(t *testing.T) {
+		c := newCueSheet(0)
+		_, _, err := c.LongestTrack()
+		require.ErrorIs(t, err, ErrInsufficientTracks)
+		_, _, err = c.ShortestTrack()
+		require.ErrorIs(t, err, ErrInsufficientTracks)
+	})
+	t.Run("TwoTracks", func(t *testing.T) {
+		c := newCueSheet(0, time.Minute)
+		for _, find := range []func() (*Track, time.Duration, error){c.LongestTrack, c.ShortestTrack} {
+			track, d, err := find()
+			require.NoError(t, err)
+			require.Same(t, &c.Tracks[0], track)
+			require.Equal(t, time.Minute, d)
+		}
+	})
+	t.Run("FiveTracks", func(t *testing.T) {
+		c := newCueSheet(0, 3*time.Minute, 4*time.Minute, 8*time.Minute, 9*time.Minute)
+		require.Equal(t, []time.Duration{3 * time.Minute, time.Minute, 4 * time.Minute, time.Minute}, c.TrackDurations())
+
+		track, d, err := c.LongestTrack()
+		require.NoError(t, err)
+		require.Same(t, &c.Tracks[2], track)
+		require.Equal(t, 4*time.Minute, d)
+
+		track, d, err = c.ShortestTrack()
+		require.NoError(t, err)
+		require.Same(t, &c.Tracks[1], track)
+		require.Equal(t, time.Minute, d)
+	})
+}
+
+func TestTrackDurationsMultipleFiles(t *testing.T) {
+	c := multipleFilesCueSheet.Clone()
+	c.Tracks = append(c.Tracks, Track{Number: 4, Type: TrackTypeAudio, File: 1, Index01: IndexPoint{Timestamp: time.Minute}})
+
+	// The second track ends the first file, its duration is unknown.
+	require.Equal(t, []time.Duration{3 * time.Minute, 0, time.Minute}, c.TrackDurations())
+
+	track, d, err := c.LongestTrack()
+	require.NoError(t, err)
+	require.Same(t, &c.Tracks[0], track)
+	require.Equal(t, 3*time.Minute, d)
+
+	track, d, err = c.ShortestTrack()
+	require.NoError(t, err)
+	require.Same(t, &c.Tracks[2], track)
+	require.Equal(t, time.Minute, d)
+
+	average, err := c.AverageTrackDuration()
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Minute, average)
+
+	require.Equal(t, 4*time.Minute, c.Tracks.Duration())
+	require.Equal(t, 3*time.Minute, multipleFilesCueSheet.Tracks.Duration())
+
+	c.Tracks[1].File = 1
+	c.Tracks[2].File = 2
+	c.Tracks[3].File = 3
+	_, _, err = c.LongestTrack()
+	require.ErrorIs(t, err, ErrInsufficientTracks)
+	_, err = c.AverageTrackDuration()
+	require.ErrorIs(t, err, ErrInsufficientTracks)
+}
+
+func TestTotalFrames(t *testing.T) {
+	// INDEX 01 00:01:00 and INDEX 01 01:00:00.
+	require.Equal(t, 1*75+60*75, allCueSheet.TotalFrames())
+
+	c := multipleIndicesCueSheet.Clone()
+	c.Tracks = append(c.Tracks, c.Tracks[1])
+	require.Equal(t, multipleIndicesCueSheet.TotalFrames(), c.TotalFrames())
+	require.Zero(t, (&CueSheet{}).TotalFrames())
+
+	// INDEX 01 00:00:00 in the second file is distinct from the one in the first file.
+	c = multipleFilesCueSheet.Clone()
+	c.Tracks[2].Index01 = IndexPoint{Timestamp: 3 * time.Minute}
+	require.Equal(t, 2*3*60*75, c.TotalFrames())
+}
+
+func TestAverageTrackDuration(t *testing.T) {
+	c := &CueSheet{
+		Tracks: []Track{
+			{Number: 1},
+			{Number: 2, Index01: IndexPoint{Timestamp: time.Minute}},
+			{Number: 3, Index01: IndexPoint{Timestamp: 3 * time.Minute}},
+			{Number: 4, Index01: IndexPoint{Timestamp: 6 * time.Minute}},
+		},
+	}
+	average, err := c.AverageTrackDuration()
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Minute, average)
+
+	c.Tracks[3].Index01 = IndexPoint{Timestamp: 6 * time.Minute, Frame: 1}
+	average, err = c.AverageTrackDuration()
+	require.NoError(t, err)
+	require.InDelta(t, 2*time.Minute+FrameToDuration(1)/3, average, 1)
+
+	c.Tracks = c.Tracks[:1]
+	_, err = c.AverageTrackDuration()
+	require.ErrorIs(t, err, ErrInsufficientTracks)
+}
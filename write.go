@@ -0,0 +1,262 @@
+package cuesheetgo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// WriteOptions configures how cue sheets are written and exported.
+type WriteOptions struct {
+	// LineEnding terminates every line, either "\n" (the default) or "\r\n".
+	LineEnding string
+	// Indent precedes TRACK lines, and twice the lines nested in a TRACK.
+	Indent string
+	// QuoteAllStrings quotes every string value, not only those that contain whitespace.
+	QuoteAllStrings bool
+	// OmitEmptyFields skips PERFORMER and TITLE lines with an empty value.
+	OmitEmptyFields bool
+	// SortRemarks writes the album remarks in alphabetical order.
+	SortRemarks bool
+	// RequireTrackTitles makes exporting fail when a track has no title.
+	RequireTrackTitles bool
+}
+
+// defaultWriteOptions are the options used by WriteCueSheet.
+var defaultWriteOptions = WriteOptions{
+	LineEnding:      "\n",
+	Indent:          "  ",
+	QuoteAllStrings: true,
+	OmitEmptyFields: true,
+}
+
+// WriteCueSheet writes the cue sheet to w in the cue sheet text format,
+// indenting with two spaces and quoting all strings.
+// The cue sheet is validated first, so that the output can be parsed back. String values
+// containing a double quote or a line break are rejected for the same reason.
+func WriteCueSheet(c *CueSheet, w io.Writer) error {
+	return WriteCueSheetWithOptions(c, w, defaultWriteOptions)
+}
+
+// WriteCueSheetWithOptions is like WriteCueSheet but allows configuring the output through opts.
+func WriteCueSheetWithOptions(c *CueSheet, w io.Writer, opts WriteOptions) error {
+	if opts.LineEnding != "" && opts.LineEnding != "\n" && opts.LineEnding != "\r\n" {
+		return fmt.Errorf("unsupported line ending: %q", opts.LineEnding)
+	}
+	if strings.TrimSpace(opts.Indent) != "" {
+		return fmt.Errorf("indent must only contain whitespace: %q", opts.Indent)
+	}
+	if err := c.validate(); err != nil {
+		return fmt.Errorf("invalid cue sheet: %w", err)
+	}
+	if err := c.validateQuotedValues(); err != nil {
+		return err
+	}
+
+	var (
+		cw          = &cueWriter{w: w, lineEnding: opts.LineEnding}
+		trackIndent = opts.Indent
+		indexIndent = opts.Indent + opts.Indent
+	)
+	for _, comment := range c.Comments {
+		cw.line("", "REM COMMENT %s", opts.quote(comment))
+	}
+	remarks := c.Remarks
+	if opts.SortRemarks {
+		remarks = slices.Clone(remarks)
+		slices.Sort(remarks)
+	}
+	for _, remark := range remarks {
+		cw.line("", "REM %s", remark)
+	}
+	if c.Date != "" {
+		cw.line("", "REM DATE %s", opts.quote(c.Date))
+	}
+	if c.DiscID != nil {
+		cw.line("", "REM DISCID %08X", *c.DiscID)
+	}
+	if c.MusicBrainzDiscID != "" {
+		cw.line("", "REM MUSICBRAINZ_DISCID %s", c.MusicBrainzDiscID)
+	}
+	if c.Barcode != "" {
+		cw.line("", "REM BARCODE %s", c.Barcode)
+	}
+	if c.DiscNumber != 0 {
+		cw.line("", "REM DISCNUMBER %d", c.DiscNumber)
+	}
+	if c.TotalDiscs != 0 {
+		cw.line("", "REM TOTALDISCS %d", c.TotalDiscs)
+	}
+	if c.AlbumGain != 0 {
+		cw.line("", "REM REPLAYGAIN_ALBUM_GAIN %s dB", formatFloat(c.AlbumGain))
+	}
+	if c.AlbumPeak != 0 {
+		cw.line("", "REM REPLAYGAIN_ALBUM_PEAK %s", formatFloat(c.AlbumPeak))
+	}
+	if c.TotalLength != nil {
+		cw.line("", "REM TOTALLENGTH %s", c.TotalLength)
+	}
+	if c.Catalog != "" {
+		cw.line("", "CATALOG %s", c.Catalog)
+	}
+	if c.AlbumPerformer != "" || !opts.OmitEmptyFields {
+		cw.line("", "PERFORMER %s", opts.quote(c.AlbumPerformer))
+	}
+	if c.AlbumTitle != "" || !opts.OmitEmptyFields {
+		cw.line("", "TITLE %s", opts.quote(c.AlbumTitle))
+	}
+	// Each FILE is written before the first track that starts in it.
+	file := 0
+	writeFiles := func(last int) {
+		for ; file <= last; file++ {
+			cw.line("", "FILE %s %s", opts.quote(c.Files[file].FileName), c.Files[file].Format)
+		}
+	}
+	for _, track := range c.Tracks {
+		writeFiles(track.File)
+		cw.line(trackIndent, "TRACK %02d %s", track.Number, track.Type)
+		if track.Title != "" || !opts.OmitEmptyFields {
+			cw.line(indexIndent, "TITLE %s", opts.quote(track.Title))
+		}
+		if track.Performer != "" {
+			cw.line(indexIndent, "PERFORMER %s", opts.quote(track.Performer))
+		}
+		if track.ISRC != "" {
+			cw.line(indexIndent, "ISRC %s", track.ISRC)
+		}
+		for _, comment := range track.Comments {
+			cw.line(indexIndent, "REM COMMENT %s", opts.quote(comment))
+		}
+		for _, remark := range track.Remarks {
+			cw.line(indexIndent, "REM %s", remark)
+		}
+		if track.TrackGain != 0 {
+			cw.line(indexIndent, "REM REPLAYGAIN_TRACK_GAIN %s dB", formatFloat(track.TrackGain))
+		}
+		if track.TrackPeak != 0 {
+			cw.line(indexIndent, "REM REPLAYGAIN_TRACK_PEAK %s", formatFloat(track.TrackPeak))
+		}
+		first := 1
+		if track.Index00 != nil {
+			first = 0
+		}
+		for j, index := range track.IndexPoints() {
+			cw.line(indexIndent, "INDEX %02d %s", first+j, index)
+		}
+	}
+	writeFiles(len(c.Files) - 1)
+	return cw.err
+}
+
+// validateQuotedValues checks that the values written by quote can be parsed back:
+// they must not contain a double quote, which would end the value, nor a line break.
+func (c *CueSheet) validateQuotedValues() error {
+	var err error
+	check := func(name, value string) {
+		if err == nil && strings.ContainsAny(value, "\"\r\n") {
+			err = fmt.Errorf("%s contains a double quote or a line break: %q", name, value)
+		}
+	}
+	check("date", c.Date)
+	check("album performer", c.AlbumPerformer)
+	check("album title", c.AlbumTitle)
+	for _, comment := range c.Comments {
+		check("comment", comment)
+	}
+	for i, file := range c.Files {
+		check(fmt.Sprintf("file %d: name", i+1), file.FileName)
+	}
+	for i, track := range c.Tracks {
+		check(fmt.Sprintf("track %d: title", i+1), track.Title)
+		check(fmt.Sprintf("track %d: performer", i+1), track.Performer)
+		for _, comment := range track.Comments {
+			check(fmt.Sprintf("track %d: comment", i+1), comment)
+		}
+	}
+	return err
+}
+
+// quote wraps s in double quotes when QuoteAllStrings is set or when s would not be parsed
+// back as a single value otherwise.
+func (o WriteOptions) quote(s string) string {
+	if o.QuoteAllStrings || s == "" || strings.ContainsAny(s, " \t") {
+		return `"` + s + `"`
+	}
+	return s
+}
+
+// String returns the cue sheet in the cue sheet text format, as written by WriteCueSheet,
+// so that it can be parsed back. For a cue sheet that cannot be written, such as an invalid
+// one, it returns the error message instead.
+func (c *CueSheet) String() string {
+	var sb strings.Builder
+	if err := WriteCueSheet(c, &sb); err != nil {
+		return err.Error()
+	}
+	return sb.String()
+}
+
+// WriteTo implements the io.WriterTo interface using WriteCueSheet.
+func (c *CueSheet) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: w}
+	err := WriteCueSheet(c, cw)
+	return cw.n, err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// MarshalText implements the encoding.TextMarshaler interface using WriteCueSheet.
+func (c *CueSheet) MarshalText() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteCueSheet(c, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface using ParseBytes.
+func (c *CueSheet) UnmarshalText(text []byte) error {
+	parsed, err := ParseBytes(text)
+	if err != nil {
+		return err
+	}
+	*c = *parsed
+	return nil
+}
+
+// cueWriter writes lines to w, keeping the first error and skipping writes after it.
+type cueWriter struct {
+	w   io.Writer
+	err error
+	// lineEnding terminates every line, "\n" when empty.
+	lineEnding string
+}
+
+func (cw *cueWriter) line(indent, format string, args ...any) {
+	if cw.err != nil {
+		return
+	}
+	lineEnding := cw.lineEnding
+	if lineEnding == "" {
+		lineEnding = "\n"
+	}
+	_, cw.err = fmt.Fprintf(cw.w, indent+format+lineEnding, args...)
+}
+
+// formatFloat formats f with the minimal precision needed to parse it back exactly.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
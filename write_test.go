@@ -0,0 +1,195 @@
+package cuesheetgo
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCueSheet(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCueSheet(&allCueSheet, &buf))
+	require.Equal(t, `PERFORMER "Sample Album Artist"
+TITLE "Sample Album"
+FILE "sample.flac" WAVE
+  TRACK 01 AUDIO
+    TITLE "First Track"
+    INDEX 01 00:01:00
+  TRACK 02 AUDIO
+    TITLE "Second Track"
+    INDEX 01 01:00:00
+`, buf.String())
+}
+
+func TestWriteCueSheetRoundTrip(t *testing.T) {
+	tcs := []struct {
+		name     string
+		cueSheet CueSheet
+	}{
+		{name: "Minimal", cueSheet: minimalCueSheet},
+		{name: "AllFields", cueSheet: allCueSheet},
+		{name: "ReplayGain", cueSheet: replayGainCueSheet},
+		{name: "DiscNumber", cueSheet: discNumberCueSheet},
+		{name: "Date", cueSheet: dateCueSheet},
+		{name: "DiscID", cueSheet: discIDCueSheet},
+		{name: "MusicBrainzDiscID", cueSheet: musicBrainzCueSheet},
+		{name: "Barcode", cueSheet: barcodeCueSheet},
+		{name: "Catalog", cueSheet: catalogCueSheet},
+		{name: "Remarks", cueSheet: remarksCueSheet},
+		{name: "Comments", cueSheet: commentsCueSheet},
+		{name: "TrackRemarks", cueSheet: trackRemarksCueSheet},
+		{name: "MultipleIndices", cueSheet: multipleIndicesCueSheet},
+		{name: "MultipleFiles", cueSheet: multipleFilesCueSheet},
+		{
+			name: "TrackMetadata",
+			cueSheet: CueSheet{
+				Files:  []FileEntry{{FileName: "sample.flac", Format: AudioFormatWave}},
+				Tracks: []Track{{Number: 1, Type: TrackTypeAudio, Performer: "First Artist", ISRC: "USRC17607839"}},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteCueSheet(&tc.cueSheet, &buf))
+			parsed, err := Parse(&buf)
+			require.NoError(t, err)
+			require.Equal(t, tc.cueSheet, *parsed)
+		})
+	}
+}
+
+func TestCueSheetString(t *testing.T) {
+	c := allCueSheet.Clone()
+	parsed, err := Parse(strings.NewReader(c.String()))
+	require.NoError(t, err)
+	require.Equal(t, c, parsed)
+
+	require.Equal(t, "invalid cue sheet: missing file name", (&CueSheet{}).String())
+}
+
+func TestWriteTo(t *testing.T) {
+	var _ io.WriterTo = (*CueSheet)(nil)
+
+	var buf bytes.Buffer
+	n, err := allCueSheet.WriteTo(&buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	parsed, err := Parse(&buf)
+	require.NoError(t, err)
+	require.Equal(t, allCueSheet, *parsed)
+
+	n, err = (&CueSheet{}).WriteTo(&buf)
+	require.ErrorContains(t, err, "missing file name")
+	require.Zero(t, n)
+}
+
+func TestWriteInvalidCueSheet(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteCueSheet(&CueSheet{}, &buf)
+	require.ErrorContains(t, err, "invalid cue sheet: missing file name")
+	require.Zero(t, buf.Len())
+}
+
+func TestCueSheetTextMarshaling(t *testing.T) {
+	text, err := allCueSheet.MarshalText()
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCueSheet(&allCueSheet, &buf))
+	require.Equal(t, buf.Bytes(), text)
+
+	var unmarshaled CueSheet
+	require.NoError(t, unmarshaled.UnmarshalText(text))
+	require.Equal(t, allCueSheet, unmarshaled)
+
+	require.Error(t, unmarshaled.UnmarshalText([]byte("FILE")))
+	require.Equal(t, allCueSheet, unmarshaled)
+}
+
+func TestWriteCueSheetWithOptions(t *testing.T) {
+	t.Run("CRLF", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteCueSheetWithOptions(&allCueSheet, &buf, WriteOptions{LineEnding: "\r\n", QuoteAllStrings: true}))
+		output := buf.String()
+		require.True(t, strings.HasPrefix(output, "PERFORMER \"Sample Album Artist\"\r\nTITLE \"Sample Album\"\r\n"))
+		require.Equal(t, strings.Count(output, "\n"), strings.Count(output, "\r\n"))
+
+		parsed, err := Parse(&buf)
+		require.NoError(t, err)
+		require.Equal(t, allCueSheet, *parsed)
+	})
+
+	t.Run("QuoteOnlyWhenNeeded", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteCueSheetWithOptions(&allCueSheet, &buf, WriteOptions{Indent: "\t"}))
+		require.Equal(t, `PERFORMER "Sample Album Artist"
+TITLE "Sample Album"
+FILE sample.flac WAVE
+	TRACK 01 AUDIO
+		TITLE "First Track"
+		INDEX 01 00:01:00
+	TRACK 02 AUDIO
+		TITLE "Second Track"
+		INDEX 01 01:00:00
+`, buf.String())
+	})
+
+	t.Run("EmptyFields", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteCueSheetWithOptions(&minimalCueSheet, &buf, WriteOptions{QuoteAllStrings: true}))
+		require.Equal(t, `PERFORMER ""
+TITLE ""
+FILE "sample.flac" WAVE
+TRACK 01 AUDIO
+TITLE ""
+INDEX 01 00:00:00
+`, buf.String())
+
+		parsed, err := Parse(&buf)
+		require.NoError(t, err)
+		require.Equal(t, minimalCueSheet, *parsed)
+	})
+
+	t.Run("SortRemarks", func(t *testing.T) {
+		c := minimalCueSheet
+		c.Remarks = []string{"LABEL Sample", "GENERATOR Some Ripper"}
+		var buf bytes.Buffer
+		require.NoError(t, WriteCueSheetWithOptions(&c, &buf, WriteOptions{SortRemarks: true, OmitEmptyFields: true}))
+		require.Equal(t, "REM GENERATOR Some Ripper\nREM LABEL Sample\nFILE sample.flac WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:00\n", buf.String())
+		require.Equal(t, []string{"LABEL Sample", "GENERATOR Some Ripper"}, c.Remarks)
+	})
+
+	t.Run("QuotedValues", func(t *testing.T) {
+		c := allCueSheet.Clone()
+		c.Tracks[0].Title = "It's a 'Track'"
+		var buf bytes.Buffer
+		require.NoError(t, WriteCueSheetWithOptions(c, &buf, WriteOptions{QuoteAllStrings: true}))
+		parsed, err := Parse(&buf)
+		require.NoError(t, err)
+		require.Equal(t, c, parsed)
+
+		c.Tracks[1].Title = `say "hi" now`
+		buf.Reset()
+		err = WriteCueSheetWithOptions(c, &buf, WriteOptions{QuoteAllStrings: true})
+		require.EqualError(t, err, `track 2: title contains a double quote or a line break: "say \"hi\" now"`)
+		require.Zero(t, buf.Len())
+
+		c.Tracks[1].Title = "Second Track"
+		c.AlbumPerformer = "Sample\r\nArtist"
+		err = WriteCueSheet(c, &buf)
+		require.EqualError(t, err, `album performer contains a double quote or a line break: "Sample\r\nArtist"`)
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := WriteCueSheetWithOptions(&allCueSheet, &buf, WriteOptions{LineEnding: "\r"})
+		require.ErrorContains(t, err, `unsupported line ending: "\r"`)
+		err = WriteCueSheetWithOptions(&allCueSheet, &buf, WriteOptions{Indent: "--"})
+		require.ErrorContains(t, err, `indent must only contain whitespace: "--"`)
+	})
+}
@@ -0,0 +1,33 @@
+package cuesheetgo
+
+import "encoding/xml"
+
+// xmlCueSheet has the fields of CueSheet without its methods, so that it is
+// encoded as an XML element instead of through CueSheet.MarshalText.
+type xmlCueSheet CueSheet
+
+// MarshalXML implements the xml.Marshaler interface. The root element is <CueSheet>.
+func (c *CueSheet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "CueSheet"}
+	return e.EncodeElement((*xmlCueSheet)(c), start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (c *CueSheet) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return d.DecodeElement((*xmlCueSheet)(c), &start)
+}
+
+// xmlIndexPoint has the fields of IndexPoint without its methods, so that it is
+// encoded as <Frame> and <Timestamp> elements instead of through IndexPoint.MarshalText.
+// The timestamp is written in nanoseconds.
+type xmlIndexPoint IndexPoint
+
+// MarshalXML implements the xml.Marshaler interface.
+func (idx IndexPoint) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(xmlIndexPoint(idx), start)
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+func (idx *IndexPoint) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	return d.DecodeElement((*xmlIndexPoint)(idx), &start)
+}
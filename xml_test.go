@@ -0,0 +1,51 @@
+package cuesheetgo
+
+import (
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestXMLMarshaling(t *testing.T) {
+	data, err := xml.Marshal(&allCueSheet)
+	require.NoError(t, err)
+	require.Equal(t, `<CueSheet>`+
+		`<AlbumPerformer>Sample Album Artist</AlbumPerformer>`+
+		`<AlbumTitle>Sample Album</AlbumTitle>`+
+		`<Files><File Format="WAVE">sample.flac</File></Files>`+
+		`<Tracks>`+
+		`<Track><Number>1</Number><Type>AUDIO</Type><Title>First Track</Title>`+
+		`<Index01><Frame>0</Frame><Timestamp>1000000000</Timestamp></Index01></Track>`+
+		`<Track><Number>2</Number><Type>AUDIO</Type><Title>Second Track</Title>`+
+		`<Index01><Frame>0</Frame><Timestamp>60000000000</Timestamp></Index01></Track>`+
+		`</Tracks>`+
+		`</CueSheet>`, string(data))
+
+	var unmarshaled CueSheet
+	require.NoError(t, xml.Unmarshal(data, &unmarshaled))
+	require.Equal(t, allCueSheet, unmarshaled)
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	totalLengthCueSheet := allCueSheet
+	totalLengthCueSheet.TotalLength = &IndexPoint{Timestamp: 2 * time.Minute, Frame: 30}
+
+	for _, cueSheet := range []CueSheet{
+		replayGainCueSheet,
+		commentsCueSheet,
+		trackRemarksCueSheet,
+		multipleIndicesCueSheet,
+		multipleFilesCueSheet,
+		discIDCueSheet,
+		totalLengthCueSheet,
+	} {
+		data, err := xml.Marshal(&cueSheet)
+		require.NoError(t, err)
+
+		var unmarshaled CueSheet
+		require.NoError(t, xml.Unmarshal(data, &unmarshaled))
+		require.Equal(t, cueSheet, unmarshaled, string(data))
+	}
+}
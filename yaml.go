@@ -0,0 +1,53 @@
+package cuesheetgo
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlCueSheet has the fields of CueSheet without its methods, so that it is
+// encoded as a YAML mapping instead of through CueSheet.MarshalText.
+type yamlCueSheet CueSheet
+
+// yamlDocument is the YAML form of a cue sheet, with the disc ID as its hexadecimal string.
+type yamlDocument struct {
+	yamlCueSheet `yaml:",inline"`
+	DiscID       string `yaml:"disc_id,omitempty"`
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (c *CueSheet) MarshalYAML() (any, error) {
+	return yamlDocument{yamlCueSheet: yamlCueSheet(*c), DiscID: c.DiscIDHex()}, nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (c *CueSheet) UnmarshalYAML(value *yaml.Node) error {
+	var doc yamlDocument
+	if err := value.Decode(&doc); err != nil {
+		return err
+	}
+	parsed := CueSheet(doc.yamlCueSheet)
+	if doc.DiscID != "" {
+		if err := parsed.parseDiscID(doc.DiscID); err != nil {
+			return err
+		}
+	}
+	*c = parsed
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface, the index point is written as MM:SS:FF.
+func (idx IndexPoint) MarshalYAML() (any, error) {
+	return idx.String(), nil
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface.
+func (idx *IndexPoint) UnmarshalYAML(value *yaml.Node) error {
+	index, err := ParseIndexPoint(value.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+	*idx = index
+	return nil
+}
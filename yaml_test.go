@@ -0,0 +1,59 @@
+package cuesheetgo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYAMLMarshaling(t *testing.T) {
+	c := allCueSheet.Clone()
+	c.DiscID = new(uint32)
+	*c.DiscID = 0x0AB12345
+
+	data, err := yaml.Marshal(c)
+	require.NoError(t, err)
+	require.Equal(t, `album_performer: Sample Album Artist
+album_title: Sample Album
+files:
+    - file_name: sample.flac
+      audio_format: WAVE
+tracks:
+    - number: 1
+      type: AUDIO
+      title: First Track
+      index01: "00:01:00"
+    - number: 2
+      type: AUDIO
+      title: Second Track
+      index01: "01:00:00"
+disc_id: 0AB12345
+`, string(data))
+
+	var unmarshaled CueSheet
+	require.NoError(t, yaml.Unmarshal(data, &unmarshaled))
+	require.Equal(t, c, &unmarshaled)
+
+	require.ErrorContains(t, yaml.Unmarshal([]byte("disc_id: 0AB123"), &unmarshaled), "invalid disc ID")
+	require.ErrorContains(t, yaml.Unmarshal([]byte("total_length: 1:2"), &unmarshaled), "line 1: ")
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	for _, cueSheet := range []CueSheet{
+		allCueSheet,
+		replayGainCueSheet,
+		commentsCueSheet,
+		trackRemarksCueSheet,
+		multipleIndicesCueSheet,
+		multipleFilesCueSheet,
+		discIDCueSheet,
+	} {
+		data, err := yaml.Marshal(&cueSheet)
+		require.NoError(t, err)
+
+		var unmarshaled CueSheet
+		require.NoError(t, yaml.Unmarshal(data, &unmarshaled))
+		require.Equal(t, cueSheet, unmarshaled, string(data))
+	}
+}